@@ -0,0 +1,110 @@
+package pushid
+
+import (
+	"fmt"
+)
+
+// Crockford's base32 alphabet, which is in ascending byte order.
+const crockfordChars = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Maps every byte to its Crockford base32 value, or -1. Lowercase letters decode like uppercase ones, and
+// I and L decode as 1 and O as 0.
+var crockfordIndex [256]int8
+
+func init() {
+	for i := range crockfordIndex {
+		crockfordIndex[i] = -1
+	}
+
+	for i := 0; i < len(crockfordChars); i++ {
+		c := crockfordChars[i]
+		crockfordIndex[c] = int8(i)
+		if c >= 'A' && c <= 'Z' {
+			crockfordIndex[c+'a'-'A'] = int8(i)
+		}
+	}
+
+	for _, alias := range []struct {
+		c byte
+		v int8
+	}{{'I', 1}, {'i', 1}, {'L', 1}, {'l', 1}, {'O', 0}, {'o', 0}} {
+		crockfordIndex[alias.c] = alias.v
+	}
+}
+
+// Encodes b as a big-endian number in Crockford base32, with zero bits prepended to make up the first
+// character when the bit count is not a multiple of 5.
+func encodeCrockford(b []byte) string {
+	out := make([]byte, (len(b)*8+4)/5)
+	j := len(out) - 1
+
+	var acc, bits uint
+	for i := len(b) - 1; i >= 0; i-- {
+		acc |= uint(b[i]) << bits
+		bits += 8
+		for bits >= 5 {
+			out[j] = crockfordChars[acc&0x1f]
+			acc >>= 5
+			bits -= 5
+			j--
+		}
+	}
+
+	if j >= 0 {
+		out[j] = crockfordChars[acc&0x1f]
+	}
+
+	return string(out)
+}
+
+// Decodes s, as produced by encodeCrockford, into dst. The prepended bits must be zero.
+func decodeCrockford(dst []byte, s string) error {
+	if len(s) != (len(dst)*8+4)/5 {
+		return fmt.Errorf("%w: base32 value %q is %d characters, should be %d", ErrInvalidLength, s, len(s), (len(dst)*8+4)/5)
+	}
+
+	j := len(dst) - 1
+
+	var acc, bits uint
+	for i := len(s) - 1; i >= 0; i-- {
+		v := crockfordIndex[s[i]]
+		if v < 0 {
+			return fmt.Errorf("%w: %q at index %d of base32 value %q", ErrInvalidCharacter, s[i], i, s)
+		}
+
+		acc |= uint(v) << bits
+		bits += 5
+		if bits >= 8 && j >= 0 {
+			dst[j] = byte(acc)
+			acc >>= 8
+			bits -= 8
+			j--
+		}
+	}
+
+	if acc != 0 {
+		return fmt.Errorf("%w: base32 value %q does not fit in %d bytes", ErrInvalidLength, s, len(dst))
+	}
+
+	return nil
+}
+
+// EncodeBase32 returns the 15 bytes of id.Bytes as 24 characters of Crockford base32, for systems that
+// are case-insensitive. The result is uppercase and sorts like the ids, but only as long as values are
+// compared case-insensitively: once a lowercased form is mixed with uppercase ones, byte order no longer
+// follows time. An invalid or zero id encodes like Nil, so DecodeBase32 returns Nil for it.
+func EncodeBase32(id PushID) string {
+	b := id.Bytes()
+	return encodeCrockford(b[:])
+}
+
+// DecodeBase32 decodes 24 characters of Crockford base32, as returned by EncodeBase32, into a PushID.
+// Decoding is case-insensitive, and I and L are read as 1 and O as 0.
+func DecodeBase32(s string) (PushID, error) {
+	var b [15]byte
+	if err := decodeCrockford(b[:], s); err != nil {
+		return "", fmt.Errorf("Invalid base32 push id: %w", err)
+	}
+
+	return FromBytes(b), nil
+}
@@ -0,0 +1,57 @@
+package pushid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBase32RoundTrip(t *testing.T) {
+	ordered, _ := shuffledIDs(t, 200, 8)
+	ordered = append([]string{string(Nil)}, append(ordered, string(Max))...)
+	encoded := make([]string, len(ordered))
+	for i, id := range ordered {
+		encoded[i] = EncodeBase32(PushID(id))
+		if len(encoded[i]) != 24 || encoded[i] != strings.ToUpper(encoded[i]) {
+			t.Fatalf("EncodeBase32(%q) = %q, want 24 uppercase characters", id, encoded[i])
+		}
+
+		for _, s := range []string{encoded[i], strings.ToLower(encoded[i])} {
+			if back, err := DecodeBase32(s); err != nil || string(back) != id {
+				t.Errorf("DecodeBase32(%q) = %q, %v, want %q", s, back, err, id)
+			}
+		}
+	}
+
+	if !IsSorted(encoded) {
+		t.Error("base32 encodings do not sort like the ids")
+	}
+}
+
+func TestBase32Aliases(t *testing.T) {
+	// 0x21 is 1 in each of the last two characters.
+	want := FromBytes([15]byte{14: 0x21})
+	if s := EncodeBase32(want); s != strings.Repeat("0", 22)+"11" {
+		t.Fatalf("EncodeBase32(%q) = %q", want, s)
+	}
+
+	for _, alias := range []string{"iI", "lL", "Il"} {
+		aliased := strings.Repeat("o", 22) + alias
+		if got, err := DecodeBase32(aliased); err != nil || got != want {
+			t.Errorf("DecodeBase32(%q) = %q, %v, want %q", aliased, got, err, want)
+		}
+	}
+
+	for _, tt := range []struct {
+		s    string
+		want error
+	}{
+		{"", ErrInvalidLength},
+		{strings.Repeat("0", 23), ErrInvalidLength},
+		{strings.Repeat("0", 23) + "U", ErrInvalidCharacter},
+	} {
+		if _, err := DecodeBase32(tt.s); !errors.Is(err, tt.want) {
+			t.Errorf("DecodeBase32(%q) error = %v, want %v", tt.s, err, tt.want)
+		}
+	}
+}
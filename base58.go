@@ -0,0 +1,30 @@
+package pushid
+
+import (
+	"fmt"
+)
+
+// The Bitcoin base58 alphabet, which leaves out 0, O, I and l and is in ascending byte order.
+const base58Chars = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Characters needed for 120 bits in base58.
+const base58Len = 21
+
+// EncodeBase58 returns the 15 bytes of id.Bytes as 21 characters of base58 with the Bitcoin alphabet,
+// left-padded with '1'. The result has no punctuation, so it can be double-click selected and pasted
+// anywhere, and it sorts like the ids. An invalid or zero id encodes like Nil, as 21 '1' characters.
+func EncodeBase58(id PushID) string {
+	b := id.Bytes()
+	return encodeRadix(b[:], base58Chars, base58Len)
+}
+
+// DecodeBase58 decodes 21 characters of base58, as returned by EncodeBase58, into a PushID. The
+// ambiguous characters 0, O, I and l are not part of the alphabet and are rejected.
+func DecodeBase58(s string) (PushID, error) {
+	var b [15]byte
+	if err := decodeRadix(b[:], s, base58Chars, base58Len); err != nil {
+		return "", fmt.Errorf("Invalid base58 push id: %w", err)
+	}
+
+	return FromBytes(b), nil
+}
@@ -0,0 +1,54 @@
+package pushid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBase58RoundTrip(t *testing.T) {
+	if got := EncodeBase58(Nil); got != strings.Repeat("1", base58Len) {
+		t.Errorf("EncodeBase58(Nil) = %q, want all ones", got)
+	}
+
+	ordered, _ := shuffledIDs(t, 200, 9)
+	ordered = append(ordered, string(Max))
+	encoded := make([]string, len(ordered))
+	for i, id := range ordered {
+		encoded[i] = EncodeBase58(PushID(id))
+		if back, err := DecodeBase58(encoded[i]); err != nil || string(back) != id {
+			t.Errorf("DecodeBase58(EncodeBase58(%q)) = %q, %v", id, back, err)
+		}
+	}
+
+	if !IsSorted(encoded) {
+		t.Error("base58 encodings do not sort like the ids")
+	}
+
+	for _, s := range []string{"", strings.Repeat("1", base58Len-1), strings.Repeat("1", base58Len-1) + "0", strings.Repeat("z", base58Len)} {
+		if _, err := DecodeBase58(s); err == nil {
+			t.Errorf("DecodeBase58(%q) returned no error", s)
+		}
+	}
+}
+
+func FuzzBase58(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 15))
+	f.Add([]byte(strings.Repeat("\xff", 15)))
+	f.Add([]byte(knownIDHex))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var b [15]byte
+		copy(b[:], data)
+		id := FromBytes(b)
+
+		s := EncodeBase58(id)
+		if len(s) != base58Len {
+			t.Fatalf("EncodeBase58(%q) = %q, want %d characters", id, s, base58Len)
+		}
+
+		if back, err := DecodeBase58(s); err != nil || back != id {
+			t.Fatalf("DecodeBase58(EncodeBase58(%q)) = %q, %v", id, back, err)
+		}
+	})
+}
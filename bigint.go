@@ -0,0 +1,50 @@
+package pushid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+)
+
+// ToBigInt returns the 120 bits of id.Bytes as an unsigned big-endian integer. Integers compare in the same
+// order as the ids they came from. An invalid or zero id converts to 0, the integer of Nil.
+func ToBigInt(id PushID) *big.Int {
+	b := id.Bytes()
+	return new(big.Int).SetBytes(b[:])
+}
+
+// FromBigInt returns the push id whose 120 bits are n. It is the inverse of ToBigInt; n must be within
+// [0, 2^120).
+func FromBigInt(n *big.Int) (PushID, error) {
+	if n.Sign() < 0 || n.BitLen() > 120 {
+		return "", fmt.Errorf("%w: %s, should be within [0, 2^120)", ErrTimestampOverflow, n)
+	}
+
+	var b [15]byte
+	n.FillBytes(b[:])
+	return FromBytes(b), nil
+}
+
+// ToUint128 returns the 120 bits of id.Bytes as a 128-bit unsigned integer split into its high and low 64
+// bits, without the allocations of ToBigInt. hi is always less than 2^56, and (hi, lo) pairs compare in
+// the same order as the ids they came from. Like ToBigInt it converts an invalid or zero id to 0, the
+// integer of Nil.
+func ToUint128(id PushID) (hi, lo uint64) {
+	var b [16]byte
+	p := id.Bytes()
+	copy(b[1:], p[:])
+	return binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+}
+
+// FromUint128 returns the push id whose 120 bits are the 128-bit integer with high and low 64 bits hi and
+// lo. It is the inverse of ToUint128; hi must be less than 2^56.
+func FromUint128(hi, lo uint64) (PushID, error) {
+	if hi >= 1<<56 {
+		return "", fmt.Errorf("%w: high bits %#x, should be less than 2^56", ErrTimestampOverflow, hi)
+	}
+
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], hi)
+	binary.BigEndian.PutUint64(b[8:], lo)
+	return FromBytes([15]byte(b[1:])), nil
+}
@@ -0,0 +1,48 @@
+package pushid
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestBigIntRoundTrip(t *testing.T) {
+	if n := ToBigInt(Nil); n.Sign() != 0 {
+		t.Errorf("ToBigInt(Nil) = %s, want 0", n)
+	}
+
+	maxInt := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 120), big.NewInt(1))
+	if n := ToBigInt(Max); n.Cmp(maxInt) != 0 {
+		t.Errorf("ToBigInt(Max) = %s, want 2^120-1", n)
+	}
+
+	ordered, _ := shuffledIDs(t, 200, 10)
+	for i, id := range ordered {
+		n := ToBigInt(PushID(id))
+		if back, err := FromBigInt(n); err != nil || string(back) != id {
+			t.Errorf("FromBigInt(ToBigInt(%q)) = %q, %v", id, back, err)
+		}
+
+		hi, lo := ToUint128(PushID(id))
+		if back, err := FromUint128(hi, lo); err != nil || string(back) != id {
+			t.Errorf("FromUint128(ToUint128(%q)) = %q, %v", id, back, err)
+		}
+
+		if i > 0 {
+			prevHi, prevLo := ToUint128(PushID(ordered[i-1]))
+			if ToBigInt(PushID(ordered[i-1])).Cmp(n) >= 0 || prevHi > hi || prevHi == hi && prevLo >= lo {
+				t.Fatalf("integers of %q and %q compare unlike the ids", ordered[i-1], id)
+			}
+		}
+	}
+
+	for _, n := range []*big.Int{big.NewInt(-1), new(big.Int).Add(maxInt, big.NewInt(1))} {
+		if _, err := FromBigInt(n); !errors.Is(err, ErrTimestampOverflow) {
+			t.Errorf("FromBigInt(%s) error = %v, want ErrTimestampOverflow", n, err)
+		}
+	}
+
+	if _, err := FromUint128(1<<56, 0); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("FromUint128 of 2^120 error = %v, want ErrTimestampOverflow", err)
+	}
+}
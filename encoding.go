@@ -0,0 +1,229 @@
+package pushid
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON encodes p as a JSON string. The zero value encodes as null, so it round-trips through
+// UnmarshalJSON; a field tagged omitempty leaves it out altogether.
+func (p PushID) MarshalJSON() ([]byte, error) {
+	if p.IsZero() {
+		return []byte("null"), nil
+	}
+
+	if err := Validate(string(p)); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(string(p))
+}
+
+// UnmarshalJSON decodes a JSON string holding a valid push id into p. JSON null sets p to the zero value.
+// The base64 form is only read by CompactPushID.
+func (p *PushID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*p = ""
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Invalid push id JSON %s: %w", data, err)
+	}
+
+	id, err := FromString(s)
+	if err != nil {
+		return err
+	}
+
+	*p = id
+	return nil
+}
+
+// CompactPushID is a PushID that encodes in JSON as the standard base64 encoding of its 15 bytes, as
+// returned by Bytes, rather than its 20 characters. Both forms are 20 characters and their alphabets share
+// the letters and digits, so a string cannot tell which one it holds: a field declares it by its type,
+// and each type only reads its own form.
+type CompactPushID PushID
+
+// MarshalJSON encodes c as a JSON string holding the base64 encoding of its bytes. The zero value encodes
+// as null.
+func (c CompactPushID) MarshalJSON() ([]byte, error) {
+	p := PushID(c)
+	if p.IsZero() {
+		return []byte("null"), nil
+	}
+
+	if err := Validate(string(p)); err != nil {
+		return nil, err
+	}
+
+	b := p.Bytes()
+	return json.Marshal(base64.StdEncoding.EncodeToString(b[:]))
+}
+
+// UnmarshalJSON decodes a JSON string holding the base64 encoding of the 15 bytes of a push id into c.
+// JSON null sets c to the zero value.
+func (c *CompactPushID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*c = ""
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Invalid compact push id JSON %s: %w", data, err)
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("Invalid compact push id %q: %w", s, err)
+	}
+
+	if len(b) != 15 {
+		return fmt.Errorf("%w: compact push id is %d bytes, should be 15", ErrInvalidLength, len(b))
+	}
+
+	*c = CompactPushID(FromBytes([15]byte(b)))
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the 20 characters of p. Like UnmarshalText it
+// rejects the zero value, which has no text form: use MarshalJSON or Value where an id may be unset.
+func (p PushID) MarshalText() ([]byte, error) {
+	if err := Validate(string(p)); err != nil {
+		return nil, err
+	}
+
+	return []byte(p), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must be a valid push id; empty text is
+// rejected like any other malformed input.
+func (p *PushID) UnmarshalText(text []byte) error {
+	id, err := FromString(string(text))
+	if err != nil {
+		return err
+	}
+
+	*p = id
+	return nil
+}
+
+// Bytes returns the 120 bits of p packed into 15 bytes, 6 bits per character with the most significant
+// bits first: the 48-bit big-endian timestamp followed by the 72 random bits as in Parsed.Random. The
+// bytes compare in the same order as the ids. An invalid p yields all zero bytes.
+func (p PushID) Bytes() [15]byte {
+	var b [15]byte
+	if !p.Valid() {
+		return b
+	}
+
+	for i := 0; i < 5; i++ {
+		c0, c1, c2, c3 := byte(pushCharIndex[p[i*4]]), byte(pushCharIndex[p[i*4+1]]), byte(pushCharIndex[p[i*4+2]]), byte(pushCharIndex[p[i*4+3]])
+		b[i*3] = c0<<2 | c1>>4
+		b[i*3+1] = c1<<4 | c2>>2
+		b[i*3+2] = c2<<6 | c3
+	}
+
+	return b
+}
+
+// FromBytes returns the PushID packed into b, the inverse of Bytes. Every 15-byte value is a valid id.
+func FromBytes(b [15]byte) PushID {
+	var id [idLen]byte
+	for i := 0; i < 5; i++ {
+		b0, b1, b2 := b[i*3], b[i*3+1], b[i*3+2]
+		id[i*4] = PUSH_CHARS[b0>>2]
+		id[i*4+1] = PUSH_CHARS[(b0&0x03)<<4|b1>>4]
+		id[i*4+2] = PUSH_CHARS[(b1&0x0f)<<2|b2>>6]
+		id[i*4+3] = PUSH_CHARS[b2&0x3f]
+	}
+
+	return PushID(id[:])
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with the 15 bytes of Bytes. The zero value marshals
+// to no bytes at all.
+func (p PushID) MarshalBinary() ([]byte, error) {
+	if p.IsZero() {
+		return []byte{}, nil
+	}
+
+	if err := Validate(string(p)); err != nil {
+		return nil, err
+	}
+
+	b := p.Bytes()
+	return b[:], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. data must be exactly 15 bytes, or empty for the
+// zero value.
+func (p *PushID) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		*p = ""
+		return nil
+	}
+
+	if len(data) != 15 {
+		return fmt.Errorf("%w: binary push id is %d bytes, should be 15", ErrInvalidLength, len(data))
+	}
+
+	*p = FromBytes([15]byte(data))
+	return nil
+}
+
+// Value implements driver.Valuer, storing p as its string form. The zero value is stored as NULL.
+func (p PushID) Value() (driver.Value, error) {
+	if p.IsZero() {
+		return nil, nil
+	}
+
+	return string(p), nil
+}
+
+// Scan implements sql.Scanner. It accepts a string or []byte holding a valid push id; NULL sets p to the
+// zero value.
+func (p *PushID) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*p = ""
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("Cannot scan %T value %v into PushID", src, src)
+	}
+
+	id, err := FromString(s)
+	if err != nil {
+		return fmt.Errorf("Scanning PushID: %w", err)
+	}
+
+	*p = id
+	return nil
+}
+
+// Set implements flag.Value, so a *PushID can be passed to flag.Var. s must be a valid push id, or empty to
+// leave p unset, which IsZero reports.
+func (p *PushID) Set(s string) error {
+	if s == "" {
+		*p = ""
+		return nil
+	}
+
+	id, err := FromString(s)
+	if err != nil {
+		return fmt.Errorf("%w; expected %d characters from %s", err, idLen, PUSH_CHARS)
+	}
+
+	*p = id
+	return nil
+}
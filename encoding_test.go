@@ -0,0 +1,357 @@
+package pushid
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	type record struct {
+		ID     PushID `json:"id"`
+		Parent PushID `json:"parent,omitempty"`
+	}
+
+	in := record{ID: knownID}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{"id":"` + string(knownID) + `"}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var out record
+	if err := json.Unmarshal(data, &out); err != nil || out != in {
+		t.Errorf("Unmarshal(%s) = %+v, %v, want %+v", data, out, err, in)
+	}
+
+	for _, id := range []PushID{"", Nil, Max} {
+		data, err := json.Marshal(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got PushID = "unset"
+		if err := json.Unmarshal(data, &got); err != nil || got != id {
+			t.Errorf("JSON round trip of %q through %s = %q, %v", id, data, got, err)
+		}
+	}
+
+	if _, err := json.Marshal(PushID("bad")); err == nil {
+		t.Error("Marshal of an invalid id returned no error")
+	}
+
+	var id PushID
+	for _, data := range []string{`"bad"`, `42`, `""`} {
+		if err := json.Unmarshal([]byte(data), &id); err == nil {
+			t.Errorf("Unmarshal(%s) returned no error", data)
+		}
+	}
+}
+
+// Ids spread over the whole 48-bit range of timestamps, with the lowest and highest random characters.
+func jsonTestIDs(t *testing.T) []PushID {
+	ids := []PushID{Nil, Max, knownID, "AAAAAAAAAAAAAAAAAAAA"}
+	for _, ms := range []int64{0, 1, 1 << 40, 1<<47 + 12345, 1<<48 - 1} {
+		lo, err := MinIDForTime(time.UnixMilli(ms))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hi, err := MaxIDForTime(time.UnixMilli(ms))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids = append(ids, PushID(lo), PushID(hi))
+	}
+
+	return ids
+}
+
+func TestJSONRoundTripFullRange(t *testing.T) {
+	for _, id := range jsonTestIDs(t) {
+		data, err := json.Marshal(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got PushID
+		if err := json.Unmarshal(data, &got); err != nil || got != id {
+			t.Errorf("JSON round trip of %q through %s = %q, %v", string(id), data, string(got), err)
+		}
+	}
+}
+
+func TestCompactPushIDJSON(t *testing.T) {
+	for _, id := range jsonTestIDs(t) {
+		data, err := json.Marshal(CompactPushID(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b := id.Bytes()
+		if want := `"` + base64.StdEncoding.EncodeToString(b[:]) + `"`; string(data) != want {
+			t.Errorf("Marshal of CompactPushID(%q) = %s, want %s", string(id), data, want)
+		}
+
+		var got CompactPushID
+		if err := json.Unmarshal(data, &got); err != nil || PushID(got) != id {
+			t.Errorf("JSON round trip of CompactPushID(%q) through %s = %q, %v", string(id), data, string(got), err)
+		}
+
+		// PushID never decodes base64: a string valid in both forms reads as the push id it spells.
+		var plain PushID
+		if err := json.Unmarshal(data, &plain); err == nil && `"`+string(plain)+`"` != string(data) {
+			t.Errorf("PushID read %s as %q", data, string(plain))
+		}
+	}
+
+	var zero CompactPushID
+	if data, err := json.Marshal(zero); err != nil || string(data) != "null" {
+		t.Errorf("Marshal of the zero CompactPushID = %s, %v, want null", data, err)
+	}
+
+	got := CompactPushID(knownID)
+	if err := json.Unmarshal([]byte("null"), &got); err != nil || got != "" {
+		t.Errorf("Unmarshal of null = %q, %v, want the zero value", string(got), err)
+	}
+
+	if _, err := json.Marshal(CompactPushID("bad")); err == nil {
+		t.Error("Marshal of an invalid CompactPushID returned no error")
+	}
+
+	for _, data := range []string{`"` + string(knownID) + `"`, `"AAAA"`, `42`, `""`} {
+		if err := json.Unmarshal([]byte(data), &got); err == nil {
+			t.Errorf("CompactPushID Unmarshal(%s) returned no error", data)
+		}
+	}
+}
+
+// Returns a new id from New, failing t on error.
+func newID(t *testing.T) PushID {
+	t.Helper()
+	id, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return id
+}
+
+func TestTextMarshaling(t *testing.T) {
+	m := map[PushID]int{knownID: 1, Max: 2}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[PushID]int
+	if err := json.Unmarshal(data, &got); err != nil || len(got) != 2 || got[knownID] != 1 || got[Max] != 2 {
+		t.Errorf("map round trip through %s = %v, %v", data, got, err)
+	}
+
+	var zero PushID
+	if text, err := zero.MarshalText(); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("zero value MarshalText = %q, %v, want ErrInvalidLength", text, err)
+	}
+
+	if err := new(PushID).UnmarshalText(nil); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("UnmarshalText of empty text error = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestBinaryMarshaling(t *testing.T) {
+	ids := make([]PushID, 200)
+	for i := range ids {
+		ids[i] = newID(t)
+	}
+
+	ids = append(ids, Nil, Max, knownID)
+	for _, id := range ids {
+		data, err := id.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(data) != 15 {
+			t.Fatalf("MarshalBinary(%q) is %d bytes", id, len(data))
+		}
+
+		var got PushID
+		if err := got.UnmarshalBinary(data); err != nil || got != id {
+			t.Errorf("UnmarshalBinary(MarshalBinary(%q)) = %q, %v", id, got, err)
+		}
+	}
+
+	for i := range ids {
+		for j := range ids {
+			a, b := ids[i].Bytes(), ids[j].Bytes()
+			if got, want := bytes.Compare(a[:], b[:]), Compare(string(ids[i]), string(ids[j])); got != want {
+				t.Fatalf("bytes.Compare of %q and %q = %d, string comparison %d", ids[i], ids[j], got, want)
+			}
+		}
+	}
+
+	for _, n := range []int{1, 14, 16, 20} {
+		if err := new(PushID).UnmarshalBinary(make([]byte, n)); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("UnmarshalBinary of %d bytes error = %v, want ErrInvalidLength", n, err)
+		}
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { knownID.Bytes() }); allocs != 0 {
+		t.Errorf("Bytes allocated %v times, want 0", allocs)
+	}
+}
+
+func TestSQLValueAndScan(t *testing.T) {
+	if v, err := knownID.Value(); err != nil || v != string(knownID) {
+		t.Errorf("Value() = %v, %v, want %q", v, err, knownID)
+	}
+
+	if v, err := PushID("").Value(); err != nil || v != nil {
+		t.Errorf("zero value Value() = %v, %v, want nil", v, err)
+	}
+
+	for _, src := range []interface{}{string(knownID), []byte(knownID)} {
+		var id PushID
+		if err := id.Scan(src); err != nil || id != knownID {
+			t.Errorf("Scan(%T) = %q, %v, want %q", src, id, err, knownID)
+		}
+	}
+
+	id := knownID
+	if err := id.Scan(nil); err != nil || !id.IsZero() {
+		t.Errorf("Scan(nil) = %q, %v, want the zero value", id, err)
+	}
+
+	for _, src := range []interface{}{42, "bad"} {
+		if err := id.Scan(src); err == nil {
+			t.Errorf("Scan(%v) returned no error", src)
+		}
+	}
+}
+
+// A database/sql driver whose every query returns the rows in fakeRows, a single column each, and whose
+// every statement records its arguments in fakeArgs.
+type fakeDriver struct{}
+
+var (
+	fakeRows []driver.Value
+	fakeArgs []driver.Value
+)
+
+func init() {
+	sql.Register("pushid-fake", fakeDriver{})
+}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt{}, nil }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+type fakeStmt struct{}
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+
+func (fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	fakeArgs = args
+	return driver.RowsAffected(1), nil
+}
+
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeResult{rows: fakeRows}, nil
+}
+
+type fakeResult struct{ rows []driver.Value }
+
+func (*fakeResult) Columns() []string { return []string{"id"} }
+func (*fakeResult) Close() error      { return nil }
+
+func (r *fakeResult) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	dest[0], r.rows = r.rows[0], r.rows[1:]
+	return nil
+}
+
+func TestSQLDatabase(t *testing.T) {
+	db, err := sql.Open("pushid-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec("INSERT", knownID, PushID("")); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fakeArgs) != 2 || fakeArgs[0] != string(knownID) || fakeArgs[1] != nil {
+		t.Errorf("Exec stored %v, want the id and NULL", fakeArgs)
+	}
+
+	fakeRows = []driver.Value{string(knownID), []byte(Max), nil}
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	var got []PushID
+	for rows.Next() {
+		var id PushID
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+
+		got = append(got, id)
+	}
+
+	if len(got) != 3 || got[0] != knownID || got[1] != Max || !got[2].IsZero() {
+		t.Errorf("scanned %q, want the id, Max and the zero value", got)
+	}
+}
+
+func TestFlagValue(t *testing.T) {
+	for _, tt := range []struct {
+		args    []string
+		want    PushID
+		wantErr bool
+	}{
+		{[]string{"-id", string(knownID)}, knownID, false},
+		{[]string{"-id", ""}, "", false},
+		{[]string{}, "", false},
+		{[]string{"-id", "bad"}, "", true},
+	} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+
+		var id PushID
+		fs.Var(&id, "id", "push id")
+
+		err := fs.Parse(tt.args)
+		if (err != nil) != tt.wantErr || id != tt.want {
+			t.Errorf("Parse(%q) = %q, %v, want %q and error %v", tt.args, id, err, tt.want, tt.wantErr)
+		}
+
+		if tt.wantErr && !strings.Contains(err.Error(), PUSH_CHARS) {
+			t.Errorf("Parse(%q) error %q does not list PUSH_CHARS", tt.args, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package pushid
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidLength is returned, wrapped, for input that does not have the required length.
+	ErrInvalidLength = errors.New("Invalid length")
+
+	// ErrInvalidCharacter matches, via errors.Is, every *CharacterError.
+	ErrInvalidCharacter = errors.New("Invalid character")
+
+	// ErrInvalidAlphabet is returned, wrapped, for a custom alphabet that is not 64 distinct bytes.
+	ErrInvalidAlphabet = errors.New("Invalid alphabet")
+
+	// ErrInvalidPrefix is returned, wrapped, for a type prefix that is not lowercase ASCII letters.
+	ErrInvalidPrefix = errors.New("Invalid prefix")
+
+	// ErrTimestampOverflow is returned, wrapped, for timestamps that do not fit in the timestamp
+	// characters, [0, 2^48) milliseconds since the Unix epoch for the default resolution, including when
+	// incrementing or decrementing an id would leave that range.
+	ErrTimestampOverflow = errors.New("Timestamp out of range")
+)
+
+// CharacterError reports a character of a push id that is not one of PUSH_CHARS.
+type CharacterError struct {
+	ID    string
+	Index int
+	Char  byte
+}
+
+func (e *CharacterError) Error() string {
+	return fmt.Sprintf("Invalid push id %q: character %q at index %d is not in PUSH_CHARS", e.ID, e.Char, e.Index)
+}
+
+// Is reports whether target is ErrInvalidCharacter.
+func (e *CharacterError) Is(target error) bool {
+	return target == ErrInvalidCharacter
+}
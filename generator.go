@@ -0,0 +1,631 @@
+package pushid
+
+import (
+	"context"
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Generator creates push IDs from its own monotonic state, independent of every other Generator.
+//
+// The zero value is ready to use, so a Generator can be embedded directly in a larger struct. A
+// Generator is safe for concurrent use by multiple goroutines and must not be copied after first use.
+type Generator struct {
+	// Guards the monotonic state and stats. lastPushTime and lastRandChars are read and updated as one unit: the
+	// collision check and the increment of the random characters must not interleave
+	// between callers or two of them can emit the same ID.
+	mu sync.Mutex
+
+	// Timestamp of the first push since the last Reset, and whether there has been one. Generate only
+	// issues ids with timestamps within [firstPushTime, lastPushTime].
+	firstPushTime int64
+	pushed        bool
+
+	// Timestamp of last push, used to prevent local collisions if you push twice in one ms. Counted in
+	// units of resolution.
+	lastPushTime int64
+
+	// We generate 72-bits of randomness which get turned into 12 characters and appended to the
+	// timestamp to prevent collisions with other clients. We store the last characters we
+	// generated because in the event of a collision, we'll use those same characters except
+	// "incremented" by one.
+	lastRandChars [randChars]int8
+
+	// The last random characters GenerateAt issued for each timestamp that Generate has not reached, so
+	// that a further id with the same timestamp increments them rather than drawing characters that may
+	// repeat an earlier id. Generate takes an entry over, and deletes it, when it reaches its timestamp.
+	backfill map[int64][randChars]int8
+
+	// Source of the current time; time.Now when nil.
+	clock func() time.Time
+
+	// Source of the 72 random bits. Used in preference to rnd; package math/rand when both are nil.
+	entropy io.Reader
+	rnd     *rand.Rand
+
+	// The 64 characters ids are encoded with; PUSH_CHARS when empty.
+	alphabet string
+
+	// Unit of lastPushTime and of the timestamp in ids.
+	resolution Resolution
+
+	// Fixed leading random characters, set by WithNodeID.
+	node    [3]int8
+	hasNode bool
+
+	// Start every timestamp's suffix at zero instead of drawing random characters.
+	sequential bool
+
+	// Encode every character as its complement, so ids sort newest first.
+	descending bool
+
+	// Counters reported by Stats.
+	stats Stats
+}
+
+// Stats counts the ids a Generator has produced.
+type Stats struct {
+	// Ids generated successfully.
+	Total uint64
+
+	// Ids that shared their timestamp with an earlier one and so incremented its random characters.
+	Collisions uint64
+
+	// Collisions in which every random character was 63, so the increment carried into the timestamp.
+	Overflows uint64
+}
+
+// Option configures a Generator created by NewGenerator.
+type Option func(*Generator)
+
+// WithClock makes the Generator read the current time from now instead of time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(g *Generator) {
+		g.clock = now
+	}
+}
+
+// WithEntropy makes the Generator read exactly 9 bytes (72 bits) from r for the random characters of
+// each ID that starts a new millisecond, instead of using package math/rand. The same r is read on every
+// call. A failed or short read is returned by Generate, wrapping the reader's error.
+func WithEntropy(r io.Reader) Option {
+	return func(g *Generator) {
+		g.entropy = r
+	}
+}
+
+// WithRand makes the Generator draw the random characters from r instead of package math/rand, so a
+// seeded r reproduces the same sequence of IDs for the same clock.
+func WithRand(r *rand.Rand) Option {
+	return func(g *Generator) {
+		g.rnd = r
+	}
+}
+
+// WithNodeID makes the first 3 random characters of every id encode node instead of random bits, so
+// generators with distinct node IDs, say one per host, can never produce the same id even in the same
+// millisecond. This trades 18 of the 72 random bits for the cross-host guarantee; ids keep their length
+// and still sort chronologically.
+func WithNodeID(node uint16) Option {
+	return func(g *Generator) {
+		g.node = [3]int8{int8(node >> 12), int8(node >> 6 & 0x3f), int8(node & 0x3f)}
+		g.hasNode = true
+	}
+}
+
+// WithSequentialSuffix replaces the random characters with a counter that starts at zero for each new
+// timestamp and is incremented for every further id with that timestamp, so the suffixes within a
+// millisecond run "------------", "-----------0", "-----------1" and so on. When the counter runs out it
+// carries into the next millisecond like the random characters do. The ids carry no randomness, so
+// generators in different processes will collide unless they also use WithNodeID.
+func WithSequentialSuffix() Option {
+	return func(g *Generator) {
+		g.sequential = true
+	}
+}
+
+// WithDescending makes the Generator emit ids that sort in reverse chronological order, for stores that
+// only iterate ascending but should list the newest entries first. Every character of the id is replaced
+// by its complement in the alphabet, the character with the value 63 minus its own: the timestamp
+// characters encode 2^48-1 minus the milliseconds, for the default resolution, and the random characters
+// are inverted too. Ids generated later, including in the same millisecond, sort strictly before earlier
+// ones. Decode them with ParseDescending; the other decoding functions read the complemented timestamp.
+// Descending and ascending ids must not be mixed in one index.
+func WithDescending() Option {
+	return func(g *Generator) {
+		g.descending = true
+	}
+}
+
+// NewGenerator returns a Generator with fresh state, configured by opts.
+func NewGenerator(opts ...Option) *Generator {
+	g := &Generator{}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// NewSecureGenerator returns a Generator that draws its random bits from crypto/rand, for IDs that must
+// not be guessable. It is NewGenerator with WithEntropy(crypto/rand.Reader) applied before opts.
+func NewSecureGenerator(opts ...Option) *Generator {
+	return NewGenerator(append([]Option{WithEntropy(cryptorand.Reader)}, opts...)...)
+}
+
+// NewDeterministic returns a Generator whose IDs are fully determined by seed and the times returned by
+// now: two such generators with the same seed and clock produce identical sequences. It is meant for
+// golden files and test fixtures only; the IDs are predictable and must never be used in production.
+func NewDeterministic(seed int64, now func() time.Time) *Generator {
+	return NewGenerator(WithRand(rand.New(rand.NewSource(seed))), WithClock(now))
+}
+
+// NewGeneratorWithAlphabet returns a Generator that encodes both the timestamp and the random characters
+// with chars instead of PUSH_CHARS. chars must be exactly 64 distinct bytes. IDs only sort chronologically
+// if chars is in ascending byte order, and the package's decoding functions, which expect PUSH_CHARS, do
+// not accept them.
+func NewGeneratorWithAlphabet(chars string, opts ...Option) (*Generator, error) {
+	if len(chars) != radix {
+		return nil, fmt.Errorf("%w: alphabet is %d bytes, should be %d", ErrInvalidAlphabet, len(chars), radix)
+	}
+
+	var seen [256]bool
+	for i := 0; i < len(chars); i++ {
+		if seen[chars[i]] {
+			return nil, fmt.Errorf("%w: character %q appears more than once", ErrInvalidAlphabet, chars[i])
+		}
+
+		seen[chars[i]] = true
+	}
+
+	g := NewGenerator(opts...)
+	g.alphabet = chars
+	return g, nil
+}
+
+func (g *Generator) now() time.Time {
+	if g.clock == nil {
+		return time.Now()
+	}
+
+	return g.clock()
+}
+
+// Draws fresh random characters into dst.
+func (g *Generator) randChars(dst *[randChars]int8) error {
+	if g.entropy == nil {
+		intn := rand.Intn
+		if g.rnd != nil {
+			intn = g.rnd.Intn
+		}
+
+		for i := 0; i < randChars; i++ {
+			dst[i] = int8(intn(radix))
+		}
+
+		return nil
+	}
+
+	var b [9]byte
+	if _, err := io.ReadFull(g.entropy, b[:]); err != nil {
+		return fmt.Errorf("Reading entropy: %w", err)
+	}
+
+	*dst = unpackRandChars(b)
+	return nil
+}
+
+// Generate returns a best-effort unique push id. See the package-level Generate for the format.
+//
+// If the clock goes backwards, for example when NTP steps it, Generate keeps using the millisecond of the
+// last ID and takes the increment path, so IDs never sort before ones already issued. Their timestamps
+// lead the wall clock until it catches up.
+func (g *Generator) Generate() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.generate()
+}
+
+// Append generates a push id and appends its bytes, as many as the length of the resolution, to dst,
+// returning the extended slice. It does not allocate when dst has room for the id.
+func (g *Generator) Append(dst []byte) ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var buf [maxIDLen]byte
+	id := buf[:g.resolution.Len()]
+	if err := g.encode(id, g.nowTicks()); err != nil {
+		return dst, err
+	}
+
+	return append(dst, id...), nil
+}
+
+// GenerateInto writes a push id into the start of dst and returns the number of bytes written, the
+// length of the resolution. It does not allocate. dst must be at least that long, 20 bytes by default.
+func (g *Generator) GenerateInto(dst []byte) (int, error) {
+	n := g.resolution.Len()
+	if len(dst) < n {
+		return 0, fmt.Errorf("%w: buffer is %d bytes, should be at least %d", ErrInvalidLength, len(dst), n)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if err := g.encode(dst[:n], g.nowTicks()); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// GenerateN returns n push ids in strictly increasing order. The lock is held and the clock is read once
+// for the whole batch, so every ID after the first takes the increment-on-collision path.
+func (g *Generator) GenerateN(n int) ([]string, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("Invalid count %d, should not be negative", n)
+	}
+
+	ids, err := g.appendN(make([]string, 0, n), n)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// GenerateNContext is GenerateN for large batches that may be abandoned: it generates the ids in chunks of
+// 1024, checking ctx before each and releasing the lock between them so other callers are not starved. If
+// ctx is done it returns the ids generated so far, still strictly increasing, together with ctx.Err().
+func (g *Generator) GenerateNContext(ctx context.Context, n int) ([]string, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("Invalid count %d, should not be negative", n)
+	}
+
+	const chunk = 1024
+
+	ids := make([]string, 0, n)
+	for len(ids) < n {
+		if err := ctx.Err(); err != nil {
+			return ids, err
+		}
+
+		var err error
+		if ids, err = g.appendN(ids, min(n-len(ids), chunk)); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// Appends n push ids to ids under g.mu, reading the clock once as GenerateN does.
+func (g *Generator) appendN(ids []string, n int) ([]string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.nowTicks()
+	for i := 0; i < n; i++ {
+		// Continue from the last ID if the random characters carried past now.
+		id, err := g.generateAt(max(now, g.lastPushTime))
+		if err != nil {
+			return ids, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// GenerateAt returns a push id whose timestamp is t instead of the current time, for backfilling
+// historical records. t must be between the Unix epoch and the end of the range of the timestamp
+// characters, 2^48 milliseconds for the default resolution.
+//
+// The Generator remembers the last id GenerateAt issued for every timestamp, so a further id with the
+// same timestamp increments its random characters as Generate does and every id sorts after the earlier
+// ones with its timestamp; the first id of a timestamp draws fresh randomness. An id for the timestamp of
+// the last one from Generate continues from that id, and Generate continues from the backfilled ids once
+// the clock reaches their timestamp, so generated-at ids interleave correctly with live ones. Ids never
+// repeat, whatever the entropy: GenerateAt returns an error for a timestamp Generate has moved past, as
+// the random characters it issued then are no longer known, and once every id of a timestamp is used up.
+// Backfill with a separate Generator to avoid the first; each timestamp backfilled takes a map entry
+// until Reset.
+func (g *Generator) GenerateAt(t time.Time) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var buf [maxIDLen]byte
+	id := buf[:g.resolution.Len()]
+
+	at := g.resolution.ticks(t)
+	if g.pushed && at == g.lastPushTime {
+		if err := g.encode(id, at); err != nil {
+			return "", err
+		}
+
+		return string(id), nil
+	}
+
+	if err := g.encodeBackfill(id, at); err != nil {
+		return "", err
+	}
+
+	return string(id), nil
+}
+
+// Reset drops the monotonic state, so the next ID draws fresh random characters even within the same
+// millisecond. IDs generated after a Reset are no longer guaranteed to sort after earlier ones; this is
+// meant for test isolation and for long-lived processes discarding accumulated increments.
+func (g *Generator) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.firstPushTime = 0
+	g.pushed = false
+	g.lastPushTime = 0
+	g.lastRandChars = [randChars]int8{}
+	g.backfill = nil
+}
+
+// Clone returns a new Generator with the configuration and monotonic state of g, taken under its lock, so
+// both continue from the last id g produced but advance independently afterwards. opts are applied to the
+// clone after the state is copied, and its Stats start at zero.
+//
+// The clone shares g's clock but never its source of randomness, so neither changes what the other draws
+// next and a clone of a deterministic Generator replays the same ids whatever g does. A Generator seeded
+// with WithRand, or reading an entropy reader other than crypto/rand.Reader, can therefore only be cloned
+// with a fresh source in opts, given by WithRand or WithEntropy; Clone returns an error otherwise.
+func (g *Generator) Clone(opts ...Option) (*Generator, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	c := &Generator{
+		firstPushTime: g.firstPushTime,
+		pushed:        g.pushed,
+		lastPushTime:  g.lastPushTime,
+		lastRandChars: g.lastRandChars,
+		backfill:      maps.Clone(g.backfill),
+		clock:         g.clock,
+		alphabet:      g.alphabet,
+		resolution:    g.resolution,
+		node:          g.node,
+		hasNode:       g.hasNode,
+		sequential:    g.sequential,
+		descending:    g.descending,
+	}
+
+	// crypto/rand.Reader is safe for concurrent use and its draws cannot be replayed anyway.
+	if g.entropy == cryptorand.Reader {
+		c.entropy = g.entropy
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if (g.entropy != nil || g.rnd != nil) && c.entropy == nil && c.rnd == nil {
+		return nil, fmt.Errorf("Cannot clone a Generator with its own source of randomness without a fresh one from WithRand or WithEntropy")
+	}
+
+	return c, nil
+}
+
+// Stats returns how many ids g has produced and how many of them took the increment-on-collision path,
+// for judging whether a finer resolution is needed. The counters only observe generation and do not affect
+// the ids; Reset does not clear them.
+func (g *Generator) Stats() Stats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.stats
+}
+
+// Must be called with g.mu held.
+func (g *Generator) generate() (string, error) {
+	return g.generateAt(g.nowTicks())
+}
+
+// Returns the current time in units of the resolution, but never one before the last ID: if the clock
+// steps back, or the random characters carried into the next tick, IDs continue from the last one
+// until the clock catches up. Must be called with g.mu held.
+func (g *Generator) nowTicks() int64 {
+	now := g.resolution.ticks(g.now())
+	if now < g.lastPushTime {
+		return g.lastPushTime
+	}
+
+	return now
+}
+
+// Must be called with g.mu held.
+func (g *Generator) generateAt(now int64) (string, error) {
+	var buf [maxIDLen]byte
+	id := buf[:g.resolution.Len()]
+	if err := g.encode(id, now); err != nil {
+		return "", err
+	}
+
+	return string(id), nil
+}
+
+// Writes the next id for the time now, in units of the resolution, into id, which must be exactly the
+// length of the resolution, updating the monotonic state. Must be called with g.mu held.
+func (g *Generator) encode(id []byte, now int64) error {
+	r := g.resolution
+	if err := r.check(now); err != nil {
+		return err
+	}
+
+	suffix := g.lastRandChars
+	collision, overflow := g.pushed && now == g.lastPushTime, false
+	if !collision {
+		if suffix, collision = g.takeBackfill(now); !collision {
+			if err := g.freshRandChars(&suffix); err != nil {
+				return err
+			}
+		}
+	}
+
+	increment := collision
+	for increment && !incrementRandChars(suffix[g.fixedChars():r.randLen()]) {
+		// Every random character was 63 and has wrapped to 0. Carry into the timestamp, which is the
+		// same as incrementing the whole id by one, so it still sorts after the previous one.
+		now++
+		overflow = true
+		if r.check(now) != nil {
+			return fmt.Errorf("%w: random characters overflowed in the last representable %s %d", ErrTimestampOverflow, r, now-1)
+		}
+
+		// The wrapped characters start the new timestamp, unless GenerateAt has issued ids with it.
+		var backfilled [randChars]int8
+		if backfilled, increment = g.takeBackfill(now); increment {
+			suffix = backfilled
+		}
+	}
+
+	if err := g.write(id, now, suffix); err != nil {
+		return err
+	}
+
+	if !g.pushed {
+		g.firstPushTime, g.pushed = now, true
+	}
+
+	g.lastPushTime = now
+	g.lastRandChars = suffix
+
+	g.stats.Total++
+	if collision {
+		g.stats.Collisions++
+	}
+	if overflow {
+		g.stats.Overflows++
+	}
+
+	return nil
+}
+
+// Writes the next id GenerateAt issues for the time at, in units of the resolution, into id, which must
+// be exactly the length of the resolution, updating the backfill state. Must be called with g.mu held.
+func (g *Generator) encodeBackfill(id []byte, at int64) error {
+	r := g.resolution
+	if err := r.check(at); err != nil {
+		return err
+	}
+
+	suffix, collision := g.backfill[at]
+	if collision {
+		if !incrementRandChars(suffix[g.fixedChars():r.randLen()]) {
+			return fmt.Errorf("Every id with the %s %d has been issued", r, at)
+		}
+	} else if g.pushed && at >= g.firstPushTime && at <= g.lastPushTime {
+		return fmt.Errorf("Generate may have issued ids at %s since the last Reset; backfill it with another Generator", r.time(at))
+	} else if err := g.freshRandChars(&suffix); err != nil {
+		return err
+	}
+
+	if err := g.write(id, at, suffix); err != nil {
+		return err
+	}
+
+	if g.backfill == nil {
+		g.backfill = make(map[int64][randChars]int8)
+	}
+
+	g.backfill[at] = suffix
+
+	g.stats.Total++
+	if collision {
+		g.stats.Collisions++
+	}
+
+	return nil
+}
+
+// Returns the last random characters GenerateAt issued for the time now, in units of the resolution, and
+// removes them from the backfill state, or reports false if there are none. Must be called with g.mu held.
+func (g *Generator) takeBackfill(now int64) ([randChars]int8, bool) {
+	suffix, ok := g.backfill[now]
+	if ok {
+		delete(g.backfill, now)
+	}
+
+	return suffix, ok
+}
+
+// Sets suffix to the random characters of the first id of a timestamp: zero or fresh random characters
+// after the node characters, if any. Must be called with g.mu held.
+func (g *Generator) freshRandChars(suffix *[randChars]int8) error {
+	if g.sequential {
+		*suffix = [randChars]int8{}
+	} else if err := g.randChars(suffix); err != nil {
+		return err
+	}
+
+	copy(suffix[:g.fixedChars()], g.node[:])
+	return nil
+}
+
+// Number of leading random characters that stay fixed: the node characters, if any. Only the ones after
+// them are random and incremented.
+func (g *Generator) fixedChars() int {
+	if g.hasNode {
+		return len(g.node)
+	}
+
+	return 0
+}
+
+// Encodes the timestamp now, in units of the resolution, and the random characters suffix into id, which
+// must be exactly the length of the resolution.
+func (g *Generator) write(id []byte, now int64, suffix [randChars]int8) error {
+	r := g.resolution
+	alphabet := g.alphabet
+	if alphabet == "" {
+		alphabet = PUSH_CHARS
+	}
+
+	// In descending mode both loops write the complement of each value, which reverses the order of ids.
+	var flip int8
+	if g.descending {
+		flip = radix - 1
+	}
+
+	pushTime := now
+	for i := r.timeLen() - 1; i >= 0; i-- {
+		id[i] = alphabet[int8(now%radix)^flip]
+		now /= radix
+	}
+
+	if now != 0 {
+		return fmt.Errorf("%w: we should have converted the entire timestamp %d", ErrTimestampOverflow, pushTime)
+	}
+
+	for i := 0; i < r.randLen(); i++ {
+		id[r.timeLen()+i] = alphabet[suffix[i]^flip]
+	}
+
+	return nil
+}
+
+// Increments chars by one as a base-64 number, reporting false if it overflowed back to all zeros.
+func incrementRandChars(chars []int8) bool {
+	var i int
+	for i = len(chars) - 1; i >= 0 && chars[i] == radix-1; i-- {
+		chars[i] = 0
+	}
+
+	if i < 0 {
+		return false
+	}
+
+	chars[i]++
+	return true
+}
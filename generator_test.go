@@ -0,0 +1,957 @@
+package pushid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Reads as an endless run of b.
+type byteReader byte
+
+func (b byteReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(b)
+	}
+
+	return len(p), nil
+}
+
+// Fails every read.
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("entropy unavailable")
+}
+
+// Returns a clock frozen at t.
+func fixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+// Returns a clock that returns each of times in turn, and the last one forever after.
+func steppedClock(times ...time.Time) func() time.Time {
+	var mu sync.Mutex
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+
+		t := times[0]
+		if len(times) > 1 {
+			times = times[1:]
+		}
+
+		return t
+	}
+}
+
+// Fails t unless ids are strictly increasing.
+func assertStrictlyIncreasing(t *testing.T, ids []string) {
+	t.Helper()
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Fatalf("id %d %q does not sort after id %d %q", i, ids[i], i-1, ids[i-1])
+		}
+	}
+}
+
+var testTime = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestGenerateConcurrent(t *testing.T) {
+	const goroutines, perGoroutine = 100, 1000
+
+	var wg sync.WaitGroup
+	results := make([][]string, goroutines)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			ids := make([]string, perGoroutine)
+			for j := range ids {
+				id, err := Generate()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				ids[j] = id
+			}
+
+			results[i] = ids
+		}(i)
+	}
+
+	wg.Wait()
+
+	seen := make(map[string]bool, goroutines*perGoroutine)
+	for _, ids := range results {
+		assertStrictlyIncreasing(t, ids)
+		for _, id := range ids {
+			if seen[id] {
+				t.Fatalf("duplicate id %q", id)
+			}
+
+			seen[id] = true
+		}
+	}
+}
+
+func TestGeneratorsAreIndependent(t *testing.T) {
+	clock := fixedClock(testTime)
+	a := NewGenerator(WithClock(clock), WithEntropy(byteReader(0)))
+	b := NewGenerator(WithClock(clock), WithEntropy(byteReader(0)))
+
+	a1, _ := a.Generate()
+	a2, _ := a.Generate()
+	b1, _ := b.Generate()
+
+	if a2 <= a1 {
+		t.Errorf("second id of a %q does not sort after %q", a2, a1)
+	}
+
+	if b1 != a1 {
+		t.Errorf("first id of b = %q, want %q: b should not see the state of a", b1, a1)
+	}
+}
+
+func TestGenerateFrozenClockIncrements(t *testing.T) {
+	g := NewGenerator(WithClock(fixedClock(testTime)))
+
+	first, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first[:timeChars] != second[:timeChars] {
+		t.Errorf("ids in a frozen millisecond %q and %q have different timestamps", first, second)
+	}
+
+	if want := mustNext(t, first); second != want {
+		t.Errorf("second id in a frozen millisecond = %q, want the incremented %q", second, want)
+	}
+}
+
+func TestWithRandReproducible(t *testing.T) {
+	g := NewGenerator(WithRand(rand.New(rand.NewSource(42))), WithClock(fixedClock(testTime)))
+
+	for _, want := range []string{"-OhweNm7lA3yUW_7kItj", "-OhweNm7lA3yUW_7kItk"} {
+		if got, err := g.Generate(); err != nil || got != want {
+			t.Errorf("Generate() = %q, %v, want %q", got, err, want)
+		}
+	}
+}
+
+func TestNewDeterministic(t *testing.T) {
+	a, _ := NewDeterministic(7, fixedClock(testTime)).GenerateN(10)
+	b, _ := NewDeterministic(7, fixedClock(testTime)).GenerateN(10)
+	c, _ := NewDeterministic(8, fixedClock(testTime)).GenerateN(10)
+
+	if !slices.Equal(a, b) {
+		t.Errorf("generators with the same seed and clock differ:\n%v\n%v", a, b)
+	}
+
+	if a[0] == c[0] {
+		t.Errorf("generators with different seeds start with the same id %q", a[0])
+	}
+}
+
+func TestWithEntropyFixedPattern(t *testing.T) {
+	for _, tt := range []struct {
+		b    byte
+		want string
+	}{
+		{0x00, "------------"},
+		{0xaa, "eeeeeeeeeeee"},
+		{0xff, "zzzzzzzzzzzz"},
+	} {
+		id, err := NewGenerator(WithEntropy(byteReader(tt.b))).Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := id[timeChars:]; got != tt.want {
+			t.Errorf("random characters with entropy %#x = %q, want %q", tt.b, got, tt.want)
+		}
+	}
+
+	if _, err := NewGenerator(WithEntropy(errReader{})).Generate(); err == nil {
+		t.Error("Generate with a failing entropy reader returned no error")
+	}
+}
+
+func TestSecureGenerator(t *testing.T) {
+	g := NewSecureGenerator(WithClock(fixedClock(testTime)))
+
+	first, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{first, second} {
+		if err := Validate(id); err != nil {
+			t.Error(err)
+		}
+	}
+
+	if second <= first {
+		t.Errorf("second id in the same millisecond %q does not sort after %q", second, first)
+	}
+
+	other, _ := NewSecureGenerator(WithClock(fixedClock(testTime))).Generate()
+	if other[timeChars:] == first[timeChars:] {
+		t.Errorf("two secure generators drew the same random characters %q", other[timeChars:])
+	}
+}
+
+func TestMustGenerate(t *testing.T) {
+	if id := MustGenerate(); !IsValid(id) {
+		t.Errorf("MustGenerate() = %q, not a valid id", id)
+	}
+
+	defer func(g *Generator) { defaultGenerator = g }(defaultGenerator)
+	defaultGenerator = NewGenerator(WithEntropy(errReader{}))
+
+	defer func() {
+		if recover() == nil {
+			t.Error("MustGenerate did not panic when Generate failed")
+		}
+	}()
+
+	MustGenerate()
+}
+
+func TestGenerateN(t *testing.T) {
+	ids, err := NewGenerator().GenerateN(100000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ids) != 100000 {
+		t.Fatalf("GenerateN(100000) returned %d ids", len(ids))
+	}
+
+	assertStrictlyIncreasing(t, ids)
+
+	if _, err := NewGenerator().GenerateN(-1); err == nil {
+		t.Error("GenerateN(-1) returned no error")
+	}
+}
+
+func TestGenerateNContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	g := NewGenerator(WithEntropy(readerFunc(func(p []byte) (int, error) {
+		cancel()
+		return byteReader(0).Read(p)
+	})))
+
+	ids, err := g.GenerateNContext(ctx, 10000)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("GenerateNContext error = %v, want context.Canceled", err)
+	}
+
+	if len(ids) == 0 || len(ids) >= 10000 {
+		t.Errorf("GenerateNContext returned %d ids, want a partial batch", len(ids))
+	}
+
+	assertStrictlyIncreasing(t, ids)
+}
+
+// Adapts a function to io.Reader.
+type readerFunc func([]byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
+
+func TestGenerateClockBackwards(t *testing.T) {
+	g := NewGenerator(WithClock(steppedClock(
+		testTime,
+		testTime.Add(time.Millisecond),
+		testTime.Add(-4*time.Millisecond),
+		testTime.Add(-3*time.Millisecond),
+		testTime.Add(2*time.Millisecond),
+	)))
+
+	ids := make([]string, 6)
+	for i := range ids {
+		id, err := g.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids[i] = id
+	}
+
+	assertStrictlyIncreasing(t, ids)
+}
+
+func TestAppend(t *testing.T) {
+	g := NewDeterministic(1, fixedClock(testTime))
+	want, _ := NewDeterministic(1, fixedClock(testTime)).Generate()
+
+	got, err := g.Append([]byte("id="))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != "id="+want {
+		t.Errorf("Append = %q, want %q", got, "id="+want)
+	}
+
+	buf := make([]byte, 0, idLen)
+	if allocs := testing.AllocsPerRun(100, func() { g.Append(buf[:0]) }); allocs != 0 {
+		t.Errorf("Append allocated %v times, want 0", allocs)
+	}
+}
+
+func TestGenerateInto(t *testing.T) {
+	g := NewDeterministic(1, fixedClock(testTime))
+	want, _ := NewDeterministic(1, fixedClock(testTime)).Generate()
+
+	buf := make([]byte, idLen)
+	if n, err := g.GenerateInto(buf); err != nil || n != idLen || string(buf) != want {
+		t.Errorf("GenerateInto = %d, %v, %q, want %d, nil, %q", n, err, buf, idLen, want)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { g.GenerateInto(buf) }); allocs != 0 {
+		t.Errorf("GenerateInto allocated %v times, want 0", allocs)
+	}
+
+	if _, err := g.GenerateInto(buf[:idLen-1]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("GenerateInto with a short buffer error = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestGeneratorWithAlphabet(t *testing.T) {
+	// Ascending byte order, as PUSH_CHARS, but starting at '0'.
+	var sb strings.Builder
+	for c := byte('0'); sb.Len() < radix; c++ {
+		sb.WriteByte(c)
+	}
+
+	chars := sb.String()
+	g, err := NewGeneratorWithAlphabet(chars, WithClock(steppedClock(testTime, testTime.Add(time.Millisecond))))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, _ := g.Generate()
+	second, _ := g.Generate()
+	if second <= first {
+		t.Errorf("custom alphabet id %q does not sort after the earlier %q", second, first)
+	}
+
+	for i := 0; i < len(first); i++ {
+		if !strings.Contains(chars, first[i:i+1]) {
+			t.Fatalf("id %q has character %q outside the custom alphabet", first, first[i])
+		}
+	}
+
+	for _, bad := range []string{chars[:radix-1], chars[:radix-1] + "0"} {
+		if _, err := NewGeneratorWithAlphabet(bad); !errors.Is(err, ErrInvalidAlphabet) {
+			t.Errorf("NewGeneratorWithAlphabet(%q) error = %v, want ErrInvalidAlphabet", bad, err)
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	g := NewGenerator(WithClock(fixedClock(testTime)), WithEntropy(byteReader(0)))
+
+	first, _ := g.Generate()
+	incremented, _ := g.Generate()
+	if incremented == first {
+		t.Fatalf("second id %q did not increment", incremented)
+	}
+
+	g.Reset()
+	if fresh, _ := g.Generate(); fresh != first {
+		t.Errorf("id after Reset = %q, want freshly drawn random characters %q", fresh, first)
+	}
+
+	// Reset also forgets the timestamps Generate has moved past, so GenerateAt accepts them again.
+	g = NewGenerator(WithClock(steppedClock(testTime, testTime.Add(time.Millisecond))))
+	g.Generate()
+	g.Generate()
+	g.Reset()
+	if id, err := g.GenerateAt(testTime); err != nil {
+		t.Errorf("GenerateAt after Reset of a millisecond Generate moved past = %q, %v", id, err)
+	}
+}
+
+func TestResetDefaultGenerators(t *testing.T) {
+	defer func(asc, desc *Generator) { defaultGenerator, defaultDescendingGenerator = asc, desc }(defaultGenerator, defaultDescendingGenerator)
+	clock := fixedClock(testTime)
+	defaultGenerator = NewGenerator(WithClock(clock), WithEntropy(byteReader(0)))
+	defaultDescendingGenerator = NewGenerator(WithClock(clock), WithEntropy(byteReader(0)), WithDescending())
+
+	asc, _ := Generate()
+	desc, _ := GenerateDescending()
+	Generate()
+	GenerateDescending()
+
+	Reset()
+	if got, _ := Generate(); got != asc {
+		t.Errorf("Generate after Reset = %q, want freshly drawn random characters %q", got, asc)
+	}
+
+	if got, _ := GenerateDescending(); got != desc {
+		t.Errorf("GenerateDescending after Reset = %q, want freshly drawn random characters %q", got, desc)
+	}
+}
+
+func TestWithNodeID(t *testing.T) {
+	clock := fixedClock(testTime)
+	a := NewGenerator(WithClock(clock), WithNodeID(1), WithEntropy(byteReader(0)))
+	b := NewGenerator(WithClock(clock), WithNodeID(2), WithEntropy(byteReader(0)))
+
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		for _, g := range []*Generator{a, b} {
+			id, err := g.Generate()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if seen[id] {
+				t.Fatalf("generators with different node IDs both produced %q", id)
+			}
+
+			seen[id] = true
+		}
+	}
+}
+
+func TestWithSequentialSuffix(t *testing.T) {
+	g := NewGenerator(WithClock(fixedClock(testTime)), WithSequentialSuffix())
+
+	for _, want := range []string{"------------", "-----------0", "-----------1", "-----------2"} {
+		id, err := g.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := id[timeChars:]; got != want {
+			t.Errorf("sequential suffix = %q, want %q", got, want)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	g := NewGenerator(WithClock(fixedClock(testTime)))
+	for i := 0; i < 1000; i++ {
+		if _, err := g.Generate(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if s := g.Stats(); s.Total != 1000 || s.Collisions != s.Total-1 || s.Overflows != 0 {
+		t.Errorf("Stats() = %+v, want 1000 ids and 999 collisions", s)
+	}
+
+	g = NewGenerator(WithClock(fixedClock(testTime)), WithEntropy(byteReader(0xff)))
+	g.Generate()
+	g.Generate()
+	if s := g.Stats(); s.Overflows != 1 {
+		t.Errorf("Stats() after an all-63 increment = %+v, want 1 overflow", s)
+	}
+}
+
+func TestWithDescending(t *testing.T) {
+	clock := steppedClock(testTime, testTime, testTime.Add(time.Millisecond), testTime.Add(time.Second))
+	asc := NewGenerator(WithClock(clock), WithRand(rand.New(rand.NewSource(1))))
+	clock = steppedClock(testTime, testTime, testTime.Add(time.Millisecond), testTime.Add(time.Second))
+	desc := NewGenerator(WithClock(clock), WithRand(rand.New(rand.NewSource(1))), WithDescending())
+
+	var prev string
+	for i := 0; i < 4; i++ {
+		a, _ := asc.Generate()
+		d, err := desc.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i > 0 && d >= prev {
+			t.Errorf("descending id %q does not sort before the earlier %q", d, prev)
+		}
+
+		prev = d
+
+		p, err := ParseDescending(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want, _ := Parse(a)
+		if !p.Time.Equal(want.Time) || p.Random != want.Random || p.Raw != d {
+			t.Errorf("ParseDescending(%q) = %+v, want the time and random bits of the ascending %q", d, p, a)
+		}
+	}
+
+	first, _ := GenerateDescending()
+	second, _ := GenerateDescending()
+	if second >= first {
+		t.Errorf("GenerateDescending %q does not sort before the earlier %q", second, first)
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := NewGenerator(WithClock(fixedClock(testTime)))
+	shared, _ := g.Generate()
+
+	c, err := g.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := mustNext(t, shared)
+
+	fromG, _ := g.Generate()
+	fromC, _ := c.Generate()
+	if fromG != want || fromC != want {
+		t.Errorf("after Clone, g and its clone generated %q and %q, want both %q", fromG, fromC, want)
+	}
+
+	next, _ := g.Generate()
+	if want := mustNext(t, fromG); next != want {
+		t.Errorf("g after its clone generated = %q, want %q", next, want)
+	}
+
+	if s := c.Stats(); s.Total != 1 {
+		t.Errorf("clone Stats() = %+v, want 1 id", s)
+	}
+
+	if _, err := NewDeterministic(1, fixedClock(testTime)).Clone(); err == nil {
+		t.Error("Clone of a seeded Generator without a fresh source returned no error")
+	}
+
+	if _, err := NewGenerator(WithEntropy(byteReader(0))).Clone(); err == nil {
+		t.Error("Clone of a Generator with an entropy reader without a fresh one returned no error")
+	}
+}
+
+func TestCloneReplaysDeterministically(t *testing.T) {
+	// Clones g after one id and returns the next ids of the clone, after g has generated busy ids of its
+	// own. Each of them draws fresh randomness, as every id is in a millisecond of its own.
+	replay := func(busy int) []string {
+		g := NewDeterministic(1, steppedClock(testTime, testTime.Add(time.Second)))
+		if _, err := g.Generate(); err != nil {
+			t.Fatal(err)
+		}
+
+		times := make([]time.Time, 5)
+		for i := range times {
+			times[i] = testTime.Add(time.Duration(i+1) * time.Millisecond)
+		}
+
+		c, err := g.Clone(WithRand(rand.New(rand.NewSource(2))), WithClock(steppedClock(times...)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := g.GenerateN(busy); err != nil {
+			t.Fatal(err)
+		}
+
+		ids := make([]string, len(times))
+		for i := range ids {
+			if ids[i], err = c.Generate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return ids
+	}
+
+	if a, b := replay(0), replay(100); !slices.Equal(a, b) {
+		t.Errorf("clones replayed different ids depending on their original:\n%v\n%v", a, b)
+	}
+}
+
+// The original Generate, before the Generator type, with the time and random draws injected, as the
+// reference for checking that the rewrites produce identical output.
+type legacyGenerator struct {
+	lastPushTime  int64
+	lastRandChars []int8
+	rnd           *rand.Rand
+}
+
+func (l *legacyGenerator) generate(now int64) string {
+	duplicateTime := now == l.lastPushTime
+	l.lastPushTime = now
+
+	timeStampChars := make([]string, 8, 8)
+	for i := 7; i >= 0; i-- {
+		pcIndex := int64(math.Mod(float64(now), 64.0))
+		timeStampChars[i] = string(PUSH_CHARS[pcIndex])
+		now = int64(math.Floor(float64(now) / 64.0))
+	}
+
+	id := strings.Join(timeStampChars, "")
+
+	if !duplicateTime {
+		for i := 0; i < 12; i++ {
+			l.lastRandChars[i] = int8(l.rnd.Intn(64))
+		}
+	} else {
+		var i int
+		for i = 11; i >= 0 && l.lastRandChars[i] == 63; i-- {
+			l.lastRandChars[i] = 0
+		}
+
+		l.lastRandChars[i]++
+	}
+
+	for i := 0; i < 12; i++ {
+		id = fmt.Sprintf("%s%s", id, string(PUSH_CHARS[l.lastRandChars[i]]))
+	}
+
+	return id
+}
+
+func TestGenerateMatchesLegacy(t *testing.T) {
+	var times []time.Time
+	for _, ms := range []int64{1, 63, 64, 4095, 1e9, 1424649600000, 1700000000123, 1<<47 + 5, 1<<48 - 2} {
+		at := time.UnixMilli(ms)
+		times = append(times, at, at, at, at.Add(time.Millisecond))
+	}
+
+	legacy := &legacyGenerator{lastRandChars: make([]int8, 12), rnd: rand.New(rand.NewSource(3))}
+	g := NewGenerator(WithClock(steppedClock(times...)), WithRand(rand.New(rand.NewSource(3))))
+	for _, at := range times {
+		want := legacy.generate(at.UnixMilli())
+		if got, err := g.Generate(); err != nil || got != want {
+			t.Errorf("Generate() at %d = %q, %v, want %q", at.UnixMilli(), got, err, want)
+		}
+	}
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	g := NewGenerator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
+
+func BenchmarkGenerateLegacy(b *testing.B) {
+	l := &legacyGenerator{lastRandChars: make([]int8, 12), rnd: rand.New(rand.NewSource(1))}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.generate(time.Now().UnixMilli())
+	}
+}
+
+func BenchmarkGenerateN(b *testing.B) {
+	g := NewGenerator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.GenerateN(100)
+	}
+}
+
+func BenchmarkGenerateLoop(b *testing.B) {
+	g := NewGenerator()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ids := make([]string, 100)
+		for j := range ids {
+			ids[j], _ = g.Generate()
+		}
+	}
+}
+
+func BenchmarkAppend(b *testing.B) {
+	g := NewGenerator()
+	buf := make([]byte, 0, idLen)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Append(buf[:0])
+	}
+}
+
+func BenchmarkGenerateInto(b *testing.B) {
+	g := NewGenerator()
+	buf := make([]byte, idLen)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.GenerateInto(buf)
+	}
+}
+
+func TestGenerateAtRoundTrip(t *testing.T) {
+	g := NewGenerator()
+	for _, at := range []time.Time{
+		time.UnixMilli(1),
+		time.Date(2015, 2, 11, 12, 0, 0, 999999999, time.UTC),
+		time.Now(),
+		time.UnixMilli(1<<48 - 1),
+	} {
+		id, err := g.GenerateAt(at)
+		if err != nil {
+			t.Fatalf("GenerateAt(%v): %v", at, err)
+		}
+
+		got, err := Timestamp(id)
+		if err != nil {
+			t.Fatalf("Timestamp(%q): %v", id, err)
+		}
+
+		if want := at.Truncate(time.Millisecond).UTC(); !got.Equal(want) {
+			t.Errorf("Timestamp(GenerateAt(%v)) = %v, want %v", at, got, want)
+		}
+	}
+
+	for _, at := range []time.Time{time.UnixMilli(-1), time.UnixMilli(1 << 48)} {
+		if _, err := g.GenerateAt(at); !errors.Is(err, ErrTimestampOverflow) {
+			t.Errorf("GenerateAt(%v) error = %v, want ErrTimestampOverflow", at, err)
+		}
+	}
+}
+
+func TestGenerateAtRepeatedMillisecond(t *testing.T) {
+	g := NewGenerator()
+	at := time.Date(2015, 2, 11, 12, 0, 0, 0, time.UTC)
+
+	first, err := g.GenerateAt(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := g.GenerateAt(at)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := mustNext(t, first); second != want {
+		t.Errorf("second GenerateAt in the same millisecond = %q, want %q", second, want)
+	}
+}
+
+func TestGenerateAtPastKeepsGenerateOrder(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < 200; i++ {
+		g := NewGenerator(WithClock(fixedClock(now)))
+
+		first, err := g.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := g.GenerateAt(now.Add(-time.Second)); err != nil {
+			t.Fatal(err)
+		}
+
+		third, err := g.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if third <= first {
+			t.Fatalf("Generate after GenerateAt(past) = %q, does not sort after %q", third, first)
+		}
+	}
+}
+
+func TestGenerateAtFutureDoesNotMoveGenerate(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	g := NewGenerator(WithClock(fixedClock(now)))
+
+	if _, err := g.GenerateAt(now.Add(24 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := Timestamp(id); !got.Equal(now) {
+		t.Errorf("Generate after GenerateAt(future) has timestamp %v, want %v", got, now)
+	}
+}
+
+func TestGenerateAtInterleavesWithGenerate(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	g := NewGenerator(WithClock(fixedClock(now)))
+
+	live, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backfilled, err := g.GenerateAt(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := mustNext(t, live); backfilled != want {
+		t.Errorf("GenerateAt(now) after Generate = %q, want %q", backfilled, want)
+	}
+
+	next, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := mustNext(t, backfilled); next != want {
+		t.Errorf("Generate after GenerateAt(now) = %q, want %q", next, want)
+	}
+
+	past, err := g.GenerateAt(now.Add(-time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if past >= live {
+		t.Errorf("GenerateAt(now-1ms) = %q, does not sort before %q", past, live)
+	}
+}
+
+func TestGenerateAtNeverRepeats(t *testing.T) {
+	next := testTime.Add(time.Millisecond)
+	for _, tt := range []struct {
+		name string
+		opts []Option
+	}{
+		{"sequential", []Option{WithSequentialSuffix()}},
+		{"constant entropy", []Option{WithEntropy(byteReader(0))}},
+	} {
+		g := NewGenerator(append(tt.opts, WithClock(steppedClock(testTime, next)))...)
+		first, _ := g.Generate()
+		second, _ := g.Generate()
+		if second <= first {
+			t.Fatalf("%s: second id %q does not sort after %q", tt.name, second, first)
+		}
+
+		// Generate has moved past the millisecond of first, so GenerateAt no longer knows which random
+		// characters it issued there.
+		if id, err := g.GenerateAt(testTime); err == nil {
+			t.Errorf("%s: GenerateAt of a millisecond Generate moved past = %q, want an error", tt.name, id)
+		}
+
+		// The current millisecond continues from Generate.
+		if id, err := g.GenerateAt(next); err != nil || id != mustNext(t, second) {
+			t.Errorf("%s: GenerateAt of the current millisecond = %q, %v, want %q", tt.name, id, err, mustNext(t, second))
+		}
+
+		g = NewGenerator(tt.opts...)
+		seen := make(map[string]bool)
+		for _, ms := range []int64{50, 60, 50, 60, 50} {
+			id, err := g.GenerateAt(time.UnixMilli(ms))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if seen[id] {
+				t.Fatalf("%s: GenerateAt(%dms) repeated %q", tt.name, ms, id)
+			}
+
+			seen[id] = true
+		}
+	}
+
+	full := NewGenerator(WithEntropy(byteReader(0xff)))
+	if _, err := full.GenerateAt(testTime); err != nil {
+		t.Fatal(err)
+	}
+
+	if id, err := full.GenerateAt(testTime); err == nil {
+		t.Errorf("GenerateAt of a used-up millisecond = %q, want an error", id)
+	}
+}
+
+func TestGenerateContinuesFromBackfill(t *testing.T) {
+	g := NewGenerator(WithEntropy(byteReader(0)), WithClock(steppedClock(testTime, testTime.Add(time.Millisecond))))
+
+	// Backfill the millisecond the clock steps to, then let Generate reach it.
+	backfilled, err := g.GenerateAt(testTime.Add(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, _ := g.Generate()
+	if first >= backfilled {
+		t.Errorf("Generate before the backfilled millisecond = %q, does not sort before %q", first, backfilled)
+	}
+
+	got, _ := g.Generate()
+	if want := mustNext(t, backfilled); got != want {
+		t.Errorf("Generate in a backfilled millisecond = %q, want %q", got, want)
+	}
+
+	// Random characters that carry into a backfilled millisecond continue from it too.
+	g = NewGenerator(WithEntropy(byteReader(0xff)), WithClock(fixedClock(testTime)))
+	if _, err := g.Generate(); err != nil {
+		t.Fatal(err)
+	}
+
+	backfilled, err = g.GenerateAt(testTime.Add(time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ = g.Generate()
+	if want := mustNext(t, backfilled); got != want {
+		t.Errorf("Generate carried into a backfilled millisecond = %q, want %q", got, want)
+	}
+}
+
+// Returns NextID(id), failing t on error.
+func mustNext(t *testing.T, id string) string {
+	t.Helper()
+	next, err := NextID(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return next
+}
+
+func TestGenerateAllRandomCharsMaxCarries(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	g := NewGenerator(WithClock(fixedClock(now)), WithEntropy(byteReader(0xff)))
+
+	first, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first[timeChars:] != "zzzzzzzzzzzz" {
+		t.Fatalf("Generate with all-0xff entropy = %q, want the random characters all 'z'", first)
+	}
+
+	second, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Validate(second); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := mustNext(t, first); second != want {
+		t.Errorf("Generate after all-63 random characters = %q, want %q", second, want)
+	}
+
+	if got, _ := Timestamp(second); !got.Equal(now.Add(time.Millisecond)) {
+		t.Errorf("carried id has timestamp %v, want %v", got, now.Add(time.Millisecond))
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/zerklabs/pushid
+
+go 1.21
@@ -0,0 +1,29 @@
+package pushid
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// EncodeHex returns the 15 bytes of id.Bytes as 30 lowercase hex characters, which sort like the ids. An
+// invalid or zero id encodes like Nil, as 30 zeros that DecodeHex turns into Nil; validate id first if
+// that must not happen.
+func EncodeHex(id PushID) string {
+	b := id.Bytes()
+	return hex.EncodeToString(b[:])
+}
+
+// DecodeHex decodes 30 hex characters of either case, as returned by EncodeHex, into a PushID. Any other
+// length is an ErrInvalidLength and any non-hex character an ErrInvalidCharacter.
+func DecodeHex(s string) (PushID, error) {
+	if len(s) != 30 {
+		return "", fmt.Errorf("%w: hex push id %q is %d characters, should be 30", ErrInvalidLength, s, len(s))
+	}
+
+	var b [15]byte
+	if _, err := hex.Decode(b[:], []byte(s)); err != nil {
+		return "", fmt.Errorf("%w: hex push id %q: %v", ErrInvalidCharacter, s, err)
+	}
+
+	return FromBytes(b), nil
+}
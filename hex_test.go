@@ -0,0 +1,46 @@
+package pushid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHexRoundTrip(t *testing.T) {
+	if got := EncodeHex(knownID); got != knownIDHex {
+		t.Errorf("EncodeHex(%q) = %q, want %q", knownID, got, knownIDHex)
+	}
+
+	for _, s := range []string{knownIDHex, strings.ToUpper(knownIDHex)} {
+		if id, err := DecodeHex(s); err != nil || id != knownID {
+			t.Errorf("DecodeHex(%q) = %q, %v, want %q", s, id, err, knownID)
+		}
+	}
+
+	ordered, _ := shuffledIDs(t, 200, 7)
+	encoded := make([]string, len(ordered))
+	for i, id := range ordered {
+		encoded[i] = EncodeHex(PushID(id))
+		if back, err := DecodeHex(encoded[i]); err != nil || string(back) != id {
+			t.Errorf("DecodeHex(EncodeHex(%q)) = %q, %v", id, back, err)
+		}
+	}
+
+	if !IsSorted(encoded) {
+		t.Error("hex encodings do not sort like the ids")
+	}
+
+	for _, tt := range []struct {
+		s    string
+		want error
+	}{
+		{"", ErrInvalidLength},
+		{knownIDHex[:29], ErrInvalidLength},
+		{knownIDHex + "00", ErrInvalidLength},
+		{"g" + knownIDHex[1:], ErrInvalidCharacter},
+	} {
+		if _, err := DecodeHex(tt.s); !errors.Is(err, tt.want) {
+			t.Errorf("DecodeHex(%q) error = %v, want %v", tt.s, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package pushid
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// PushID is a push id as a typed value. The zero value is the empty string, which is never a valid push
+// id, so it can stand for "no id".
+type PushID string
+
+const (
+	// Nil is the smallest possible push id, every character the first of PUSH_CHARS. Unlike the zero value
+	// it is a valid id, and sorts before every id Generate produces.
+	Nil PushID = "--------------------"
+
+	// Max is the largest possible push id, every character the last of PUSH_CHARS. It sorts after every id
+	// Generate produces.
+	Max PushID = "zzzzzzzzzzzzzzzzzzzz"
+)
+
+// RFC 3339 with the millisecond precision of the timestamp.
+const millisTimeFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// New returns a new PushID from the default generator.
+func New() (PushID, error) {
+	id, err := Generate()
+	if err != nil {
+		return "", err
+	}
+
+	return PushID(id), nil
+}
+
+// FromString validates s and returns it as a PushID.
+func FromString(s string) (PushID, error) {
+	if err := Validate(s); err != nil {
+		return "", err
+	}
+
+	return PushID(s), nil
+}
+
+// String returns the 20-character form of p.
+func (p PushID) String() string {
+	return string(p)
+}
+
+// IsZero reports whether p is the zero value.
+func (p PushID) IsZero() bool {
+	return p == ""
+}
+
+// IsNil reports whether p is Nil.
+func (p PushID) IsNil() bool {
+	return p == Nil
+}
+
+// IsMax reports whether p is Max.
+func (p PushID) IsMax() bool {
+	return p == Max
+}
+
+// Valid reports whether p is a well-formed push id.
+func (p PushID) Valid() bool {
+	return IsValid(string(p))
+}
+
+// Time returns the time p was generated at, in UTC and at millisecond precision.
+func (p PushID) Time() (time.Time, error) {
+	return Timestamp(string(p))
+}
+
+// Age returns how long ago p was generated; see the package-level Age.
+func (p PushID) Age() (time.Duration, error) {
+	return Age(string(p))
+}
+
+// Compare returns -1, 0 or +1 depending on whether p sorts before, equal to or after other.
+func (p PushID) Compare(other PushID) int {
+	return Compare(string(p), string(other))
+}
+
+// Equal reports whether p and other are the same id.
+func (p PushID) Equal(other PushID) bool {
+	return p == other
+}
+
+// Before reports whether p sorts before other. Like Compare it is purely lexicographic; since ids sort
+// chronologically that means p was generated first, but no timestamps are decoded.
+func (p PushID) Before(other PushID) bool {
+	return p.Compare(other) < 0
+}
+
+// After reports whether p sorts after other.
+func (p PushID) After(other PushID) bool {
+	return p.Compare(other) > 0
+}
+
+// Format implements fmt.Formatter. %s and %v print the 20 characters of p, %x and %X the 15 bytes of
+// Bytes in hex, %d the Unix millisecond timestamp and %+v the timestamp in RFC 3339 followed by a slash
+// and the id. Other verbs, and verbs other than %s and %v on an invalid p, print as %!verb(pushid.PushID=p).
+func (p PushID) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 's' || verb == 'v' && !f.Flag('+'):
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), string(p))
+	case !p.Valid():
+		fmt.Fprintf(f, "%%!%c(pushid.PushID=%s)", verb, string(p))
+	case verb == 'v':
+		t, _ := p.Time()
+		fmt.Fprintf(f, "%s/%s", t.Format(millisTimeFormat), string(p))
+	case verb == 'x' || verb == 'X':
+		b := p.Bytes()
+		fmt.Fprintf(f, fmt.FormatString(f, verb), b[:])
+	case verb == 'd':
+		t, _ := p.Time()
+		fmt.Fprintf(f, fmt.FormatString(f, verb), t.UnixMilli())
+	default:
+		fmt.Fprintf(f, "%%!%c(pushid.PushID=%s)", verb, string(p))
+	}
+}
+
+// LogValue implements slog.LogValuer, logging p as a group of the id and its timestamp. The zero value,
+// and any other invalid p, logs as a plain string.
+func (p PushID) LogValue() slog.Value {
+	t, err := p.Time()
+	if err != nil {
+		return slog.StringValue(string(p))
+	}
+
+	return slog.GroupValue(
+		slog.String("id", string(p)),
+		slog.String("time", t.Format(millisTimeFormat)),
+	)
+}
@@ -0,0 +1,114 @@
+package pushid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// A known id, from the Firebase announcement, and its 15 bytes in hex.
+const (
+	knownID    PushID = "-JhLeOlGIEjaIOFHR0xd"
+	knownIDHex        = "014b56a99c514cfbe64d9412701f69"
+)
+
+func TestPushIDMethods(t *testing.T) {
+	id, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id.String() != string(id) || !id.Valid() || id.IsZero() || id.IsNil() || id.IsMax() {
+		t.Errorf("methods of the generated %q report String %q, Valid %v, IsZero %v, IsNil %v, IsMax %v",
+			id, id.String(), id.Valid(), id.IsZero(), id.IsNil(), id.IsMax())
+	}
+
+	if ts, err := id.Time(); err != nil || time.Since(ts) > time.Minute {
+		t.Errorf("Time() = %v, %v, want about now", ts, err)
+	}
+
+	if got, err := FromString(string(id)); err != nil || got != id {
+		t.Errorf("FromString(%q) = %q, %v", id, got, err)
+	}
+
+	if _, err := FromString("bad"); err == nil {
+		t.Error("FromString(bad) returned no error")
+	}
+
+	var zero PushID
+	if !zero.IsZero() || zero.Valid() || !Nil.IsNil() || !Max.IsMax() {
+		t.Error("zero value, Nil or Max misreported by IsZero, Valid, IsNil or IsMax")
+	}
+}
+
+func TestPushIDOrdering(t *testing.T) {
+	ids := make([]PushID, 100)
+	for i := range ids {
+		ids[i], _ = New()
+	}
+
+	for i := 1; i < len(ids); i++ {
+		a, b := ids[i-1], ids[i]
+		if a.Compare(b) != Compare(string(a), string(b)) || !a.Before(b) || !b.After(a) || a.Equal(b) || !a.Equal(a) {
+			t.Fatalf("methods of %q and %q disagree with Compare", a, b)
+		}
+	}
+
+	for _, id := range ids {
+		if !Nil.Before(id) || !Max.After(id) {
+			t.Errorf("%q does not sort between Nil and Max", id)
+		}
+	}
+}
+
+func TestPushIDFormat(t *testing.T) {
+	var zero PushID
+	for _, tt := range []struct {
+		format string
+		id     PushID
+		want   string
+	}{
+		{"%s", knownID, string(knownID)},
+		{"%v", knownID, string(knownID)},
+		{"%24s", knownID, "    " + string(knownID)},
+		{"%-22v|", knownID, string(knownID) + "  |"},
+		{"%x", knownID, knownIDHex},
+		{"%X", knownID, "014B56A99C514CFBE64D9412701F69"},
+		{"%d", knownID, "1423088131153"},
+		{"%+v", knownID, "2015-02-04T22:15:31.153Z/" + string(knownID)},
+		{"%q", knownID, "%!q(pushid.PushID=" + string(knownID) + ")"},
+		{"%s", zero, ""},
+		{"%v", zero, ""},
+		{"%d", zero, "%!d(pushid.PushID=)"},
+		{"%x", "bad", "%!x(pushid.PushID=bad)"},
+	} {
+		if got := fmt.Sprintf(tt.format, tt.id); got != tt.want {
+			t.Errorf("Sprintf(%q, %q) = %q, want %q", tt.format, string(tt.id), got, tt.want)
+		}
+	}
+}
+
+func TestPushIDLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("created", "user", knownID, "missing", PushID(""))
+
+	var record struct {
+		User struct {
+			ID   string `json:"id"`
+			Time string `json:"time"`
+		} `json:"user"`
+		Missing string `json:"missing"`
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("unmarshaling %s: %v", buf.Bytes(), err)
+	}
+
+	if record.User.ID != string(knownID) || record.User.Time != "2015-02-04T22:15:31.153Z" || record.Missing != "" {
+		t.Errorf("logged %s, want user.id, user.time and an empty missing", buf.Bytes())
+	}
+}
@@ -0,0 +1,31 @@
+package pushid
+
+import (
+	cryptorand "crypto/rand"
+	"fmt"
+)
+
+// Longest key RandomKey returns.
+const maxRandomKeyLen = 1024
+
+// RandomKey returns n characters drawn uniformly from PUSH_CHARS with crypto/rand, for opaque keys and
+// secrets that must fit the same columns and validators as push ids without revealing when they were
+// made. They are not push ids and do not sort chronologically, although IsValid accepts those of 20
+// characters. n must be within [1, 1024].
+func RandomKey(n int) (string, error) {
+	if n < 1 || n > maxRandomKeyLen {
+		return "", fmt.Errorf("%w: key length %d, should be within [1, %d]", ErrInvalidLength, n, maxRandomKeyLen)
+	}
+
+	b := make([]byte, n)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", fmt.Errorf("Reading entropy: %w", err)
+	}
+
+	// 256 is a multiple of radix, so keeping the low 6 bits of each byte is uniform.
+	for i := range b {
+		b[i] = PUSH_CHARS[b[i]%radix]
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,55 @@
+package pushid
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestRandomKey(t *testing.T) {
+	for _, n := range []int{1, 20, maxRandomKeyLen} {
+		key, err := RandomKey(n)
+		if err != nil || len(key) != n {
+			t.Fatalf("RandomKey(%d) = %q, %v", n, key, err)
+		}
+
+		for i := 0; i < len(key); i++ {
+			if strings.IndexByte(PUSH_CHARS, key[i]) < 0 {
+				t.Fatalf("RandomKey(%d) = %q, with %q outside PUSH_CHARS", n, key, key[i])
+			}
+		}
+	}
+
+	for _, n := range []int{-1, 0, maxRandomKeyLen + 1} {
+		if _, err := RandomKey(n); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("RandomKey(%d) error = %v, want ErrInvalidLength", n, err)
+		}
+	}
+}
+
+func TestRandomKeyUniform(t *testing.T) {
+	const keys = 200
+	var counts [radix]int
+	for i := 0; i < keys; i++ {
+		key, err := RandomKey(maxRandomKeyLen)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for j := 0; j < len(key); j++ {
+			counts[strings.IndexByte(PUSH_CHARS, key[j])]++
+		}
+	}
+
+	// A chi-squared test with 63 degrees of freedom; 120 is far beyond its 99.999th percentile.
+	expected := float64(keys*maxRandomKeyLen) / radix
+	chi2 := 0.0
+	for _, c := range counts {
+		chi2 += math.Pow(float64(c)-expected, 2) / expected
+	}
+
+	if chi2 > 120 {
+		t.Errorf("character counts %v have chi-squared %.1f, want uniform", counts, chi2)
+	}
+}
@@ -0,0 +1,61 @@
+package pushid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// KSUID timestamps count seconds from 2014-05-13T16:53:20Z.
+	ksuidEpoch = 1400000000
+
+	ksuidChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// ToKSUID converts id to a KSUID, 27 base62 characters. KSUIDs only hold whole seconds, so the payload
+// starts with the remaining milliseconds as a big-endian uint16, followed by the 72 random bits and 5 zero
+// bytes. That lets FromKSUID reconstruct id exactly, and KSUIDs made this way sort like the ids they came
+// from. ids before the KSUID epoch, 2014-05-13T16:53:20Z, or past its 2^32 seconds are rejected.
+func ToKSUID(id PushID) (string, error) {
+	t, err := id.Time()
+	if err != nil {
+		return "", err
+	}
+
+	millis := t.UnixMilli()
+	secs := millis/1000 - ksuidEpoch
+	if secs < 0 || secs >= 1<<32 {
+		return "", fmt.Errorf("%w: %s is outside the KSUID time range", ErrTimestampOverflow, t)
+	}
+
+	var k [20]byte
+	b := id.Bytes()
+	binary.BigEndian.PutUint32(k[:4], uint32(secs))
+	binary.BigEndian.PutUint16(k[4:6], uint16(millis%1000))
+	copy(k[6:15], b[6:])
+	return encodeRadix(k[:], ksuidChars, 27), nil
+}
+
+// FromKSUID converts a KSUID produced by ToKSUID back to the id it came from. KSUIDs with any other payload
+// are rejected, as their milliseconds and random bits cannot be recovered.
+func FromKSUID(s string) (PushID, error) {
+	var k [20]byte
+	if err := decodeRadix(k[:], s, ksuidChars, 27); err != nil {
+		return "", fmt.Errorf("Invalid KSUID: %w", err)
+	}
+
+	rem := binary.BigEndian.Uint16(k[4:6])
+	if rem >= 1000 || k[15]|k[16]|k[17]|k[18]|k[19] != 0 {
+		return "", fmt.Errorf("KSUID %q was not produced by ToKSUID", s)
+	}
+
+	millis := (int64(binary.BigEndian.Uint32(k[:4]))+ksuidEpoch)*1000 + int64(rem)
+
+	var b [15]byte
+	for i := 0; i < 6; i++ {
+		b[i] = byte(millis >> (40 - 8*i))
+	}
+
+	copy(b[6:], k[6:15])
+	return FromBytes(b), nil
+}
@@ -0,0 +1,44 @@
+package pushid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestKSUIDRoundTrip(t *testing.T) {
+	ordered, _ := shuffledIDs(t, 500, 6)
+	ksuids := make([]string, len(ordered))
+	for i, id := range ordered {
+		k, err := ToKSUID(PushID(id))
+		if err != nil || len(k) != 27 {
+			t.Fatalf("ToKSUID(%q) = %q, %v", id, k, err)
+		}
+
+		if got, err := FromKSUID(k); err != nil || string(got) != id {
+			t.Errorf("FromKSUID(ToKSUID(%q)) = %q, %v", id, got, err)
+		}
+
+		ksuids[i] = k
+	}
+
+	if !IsSorted(ksuids) {
+		t.Error("KSUIDs do not sort like the ids")
+	}
+
+	for _, at := range []time.Time{time.Unix(ksuidEpoch-1, 0), time.Unix(ksuidEpoch+1<<32, 0)} {
+		id, err := NewGenerator().GenerateAt(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ToKSUID(PushID(id)); !errors.Is(err, ErrTimestampOverflow) {
+			t.Errorf("ToKSUID of an id at %v error = %v, want ErrTimestampOverflow", at, err)
+		}
+	}
+
+	// A KSUID from another generator, whose payload holds random bits where ToKSUID puts zeros.
+	if _, err := FromKSUID("0ujtsYcgvSTl8PAuAdqWYSMnLOv"); err == nil {
+		t.Error("FromKSUID of a foreign KSUID returned no error")
+	}
+}
@@ -0,0 +1,107 @@
+package pushid
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// NextID returns the smallest push id that sorts strictly after id, treating all 20 characters as one
+// base-64 number over PUSH_CHARS and carrying from the random characters into the timestamp. It is
+// useful as the exclusive bound of a range scan. The maximum id, all 'z', has no successor.
+func NextID(id string) (string, error) {
+	if err := Validate(id); err != nil {
+		return "", err
+	}
+
+	next := []byte(id)
+	for i := len(next) - 1; i >= 0; i-- {
+		pcIndex := pushCharIndex[next[i]]
+		if pcIndex < radix-1 {
+			next[i] = PUSH_CHARS[pcIndex+1]
+			return string(next), nil
+		}
+
+		next[i] = PUSH_CHARS[0]
+	}
+
+	return "", fmt.Errorf("%w: push id %q is the maximum and has no successor", ErrTimestampOverflow, id)
+}
+
+// PrevID returns the largest push id that sorts strictly before id, borrowing across characters the same
+// way NextID carries. NextID(PrevID(id)) == id for every valid id but the minimum, all '-', which has no
+// predecessor.
+func PrevID(id string) (string, error) {
+	if err := Validate(id); err != nil {
+		return "", err
+	}
+
+	prev := []byte(id)
+	for i := len(prev) - 1; i >= 0; i-- {
+		pcIndex := pushCharIndex[prev[i]]
+		if pcIndex > 0 {
+			prev[i] = PUSH_CHARS[pcIndex-1]
+			return string(prev), nil
+		}
+
+		prev[i] = PUSH_CHARS[radix-1]
+	}
+
+	return "", fmt.Errorf("%w: push id %q is the minimum and has no predecessor", ErrTimestampOverflow, id)
+}
+
+// Next returns the smallest push id that sorts strictly after p, with no id between them. See NextID.
+func (p PushID) Next() (PushID, error) {
+	next, err := NextID(string(p))
+	return PushID(next), err
+}
+
+// Prev returns the largest push id that sorts strictly before p, with no id between them. See PrevID.
+func (p PushID) Prev() (PushID, error) {
+	prev, err := PrevID(string(p))
+	return PushID(prev), err
+}
+
+// Add returns the push id n places after p, treating its 120 bits as one unsigned integer and carrying from
+// the random characters into the timestamp, so p.Add(1) is p.Next(). A negative n moves before p. Results
+// past Max or before Nil return an error wrapping ErrTimestampOverflow.
+func (p PushID) Add(n int64) (PushID, error) {
+	if n < 0 {
+		// uint64(-n) is also right for math.MinInt64, whose negation wraps to itself.
+		return p.offset(uint64(-n), true)
+	}
+
+	return p.offset(uint64(n), false)
+}
+
+// Sub returns the push id n places before p. It is p.Add(-n), but also accepts math.MinInt64.
+func (p PushID) Sub(n int64) (PushID, error) {
+	if n < 0 {
+		return p.offset(uint64(-n), false)
+	}
+
+	return p.offset(uint64(n), true)
+}
+
+// Moves p by delta places, backwards if neg.
+func (p PushID) offset(delta uint64, neg bool) (PushID, error) {
+	if err := Validate(string(p)); err != nil {
+		return "", err
+	}
+
+	hi, lo := ToUint128(p)
+
+	var carry uint64
+	if neg {
+		lo, carry = bits.Sub64(lo, delta, 0)
+		if hi, carry = bits.Sub64(hi, 0, carry); carry != 0 {
+			return "", fmt.Errorf("%w: push id %q minus %d is before the minimum", ErrTimestampOverflow, string(p), delta)
+		}
+	} else {
+		lo, carry = bits.Add64(lo, delta, 0)
+		if hi += carry; hi >= 1<<56 {
+			return "", fmt.Errorf("%w: push id %q plus %d is past the maximum", ErrTimestampOverflow, string(p), delta)
+		}
+	}
+
+	return FromUint128(hi, lo)
+}
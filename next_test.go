@@ -0,0 +1,113 @@
+package pushid
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNextID(t *testing.T) {
+	for _, tt := range []struct{ id, want string }{
+		{"-JhLeOlGIEjaIOFHR0xd", "-JhLeOlGIEjaIOFHR0xe"},
+		{"-JhLeOlGIEjaIOFHR0xz", "-JhLeOlGIEjaIOFHR0y-"},
+		{"-JhLeOlGzzzzzzzzzzzz", "-JhLeOlH------------"},
+	} {
+		if got, err := NextID(tt.id); err != nil || got != tt.want {
+			t.Errorf("NextID(%q) = %q, %v, want %q", tt.id, got, err, tt.want)
+		}
+	}
+
+	if _, err := NextID(string(Max)); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("NextID(Max) error = %v, want ErrTimestampOverflow", err)
+	}
+}
+
+func TestPrevID(t *testing.T) {
+	for _, tt := range []struct{ id, want string }{
+		{"-JhLeOlGIEjaIOFHR0xd", "-JhLeOlGIEjaIOFHR0xc"},
+		{"-JhLeOlGIEjaIOFHR0y-", "-JhLeOlGIEjaIOFHR0xz"},
+		{"-JhLeOlH------------", "-JhLeOlGzzzzzzzzzzzz"},
+	} {
+		if got, err := PrevID(tt.id); err != nil || got != tt.want {
+			t.Errorf("PrevID(%q) = %q, %v, want %q", tt.id, got, err, tt.want)
+		}
+	}
+
+	if _, err := PrevID(string(Nil)); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("PrevID(Nil) error = %v, want ErrTimestampOverflow", err)
+	}
+}
+
+func TestNextPrevAdjacent(t *testing.T) {
+	one := big.NewInt(1)
+	for i := 0; i < 1000; i++ {
+		id, _ := New()
+
+		next, err := id.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		prev, err := id.Prev()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		n := ToBigInt(id)
+		if d := new(big.Int).Sub(ToBigInt(next), n); d.Cmp(one) != 0 || next <= id {
+			t.Fatalf("%q.Next() = %q, %s values after it", id, next, d)
+		}
+
+		if d := new(big.Int).Sub(n, ToBigInt(prev)); d.Cmp(one) != 0 || prev >= id {
+			t.Fatalf("%q.Prev() = %q, %s values before it", id, prev, d)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	id, _ := New()
+	next, _ := id.Next()
+	if got, err := id.Add(1); err != nil || got != next {
+		t.Errorf("Add(1) = %q, %v, want Next() %q", got, err, next)
+	}
+
+	for _, tt := range []struct {
+		id   PushID
+		n    int64
+		want PushID
+	}{
+		{"-JhLeOlGzzzzzzzzzzzz", 1, "-JhLeOlH------------"},
+		{"-JhLeOlGzzzzzzzzzzzy", 3, "-JhLeOlH-----------0"},
+		{"-JhLeOlH------------", -1, "-JhLeOlGzzzzzzzzzzzz"},
+		{"-JhLeOlGIEjaIOFHR0xd", -64, "-JhLeOlGIEjaIOFHR0wd"},
+		{"zzzzzzzzzzzzzzzzzzzy", 1, Max},
+		{Nil, 0, Nil},
+	} {
+		if got, err := tt.id.Add(tt.n); err != nil || got != tt.want {
+			t.Errorf("%q.Add(%d) = %q, %v, want %q", tt.id, tt.n, got, err, tt.want)
+		}
+
+		if got, err := tt.want.Sub(tt.n); err != nil || got != tt.id {
+			t.Errorf("%q.Sub(%d) = %q, %v, want %q", tt.want, tt.n, got, err, tt.id)
+		}
+	}
+
+	for _, tt := range []struct {
+		id PushID
+		n  int64
+	}{
+		{"zzzzzzzzzzzzzzzzzzzy", 2},
+		{Max, math.MaxInt64},
+		{Nil, -1},
+		{Nil, math.MinInt64},
+	} {
+		if _, err := tt.id.Add(tt.n); !errors.Is(err, ErrTimestampOverflow) {
+			t.Errorf("%q.Add(%d) error = %v, want ErrTimestampOverflow", tt.id, tt.n, err)
+		}
+	}
+
+	if _, err := Nil.Sub(math.MinInt64); err != nil {
+		t.Errorf("Nil.Sub(MinInt64) error = %v, want 2^63 places after Nil", err)
+	}
+}
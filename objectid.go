@@ -0,0 +1,55 @@
+package pushid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ToObjectID converts id to the 12 bytes of a MongoDB ObjectID: the timestamp in whole seconds as a
+// big-endian uint32, followed by the first 64 of the 72 random bits. The conversion is lossy: the
+// milliseconds and the last 8 random bits are dropped. ids past the uint32 seconds range, in 2106, are
+// rejected.
+func ToObjectID(id PushID) ([12]byte, error) {
+	return toSecondsLayout(id, "ObjectID")
+}
+
+// FromObjectID converts an ObjectID to a push id whose timestamp is the ObjectID's second and whose random
+// bits start with the ObjectID's other 8 bytes, zero-filled. Decoding its time therefore matches the
+// ObjectID's to the second, with zero milliseconds.
+func FromObjectID(oid [12]byte) PushID {
+	return fromSecondsLayout(oid)
+}
+
+// Lays id out as 4 bytes of big-endian seconds followed by its first 8 random bytes, as ObjectIDs and xids
+// both do.
+func toSecondsLayout(id PushID, kind string) ([12]byte, error) {
+	var out [12]byte
+
+	t, err := id.Time()
+	if err != nil {
+		return out, err
+	}
+
+	secs := t.Unix()
+	if secs >= 1<<32 {
+		return out, fmt.Errorf("%w: %s is outside the %s time range", ErrTimestampOverflow, t, kind)
+	}
+
+	b := id.Bytes()
+	binary.BigEndian.PutUint32(out[:4], uint32(secs))
+	copy(out[4:], b[6:14])
+	return out, nil
+}
+
+// Inverse of toSecondsLayout, up to the precision it dropped.
+func fromSecondsLayout(in [12]byte) PushID {
+	millis := int64(binary.BigEndian.Uint32(in[:4])) * 1000
+
+	var b [15]byte
+	for i := 0; i < 6; i++ {
+		b[i] = byte(millis >> (40 - 8*i))
+	}
+
+	copy(b[6:14], in[4:])
+	return FromBytes(b)
+}
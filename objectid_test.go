@@ -0,0 +1,56 @@
+package pushid
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestObjectIDRoundTrip(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 678e6, time.UTC)
+	id, _ := NewGenerator().GenerateAt(at)
+
+	oid, err := ToObjectID(PushID(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	back := FromObjectID(oid)
+	if ts, _ := back.Time(); !ts.Equal(at.Truncate(time.Second)) {
+		t.Errorf("FromObjectID(ToObjectID(%q)) has time %v, want %v", id, ts, at.Truncate(time.Second))
+	}
+
+	// Only the milliseconds and the last random byte are lost.
+	b, want := back.Bytes(), PushID(id).Bytes()
+	if !bytes.Equal(b[6:14], want[6:14]) || b[14] != 0 {
+		t.Errorf("FromObjectID(ToObjectID(%q)) = %q, want its first 8 random bytes", id, back)
+	}
+
+	if again, _ := ToObjectID(back); again != oid {
+		t.Errorf("ObjectID %x does not round-trip through %q, got %x", oid, back, again)
+	}
+
+	late, _ := NewGenerator().GenerateAt(time.Unix(1<<32, 0))
+	if _, err := ToObjectID(PushID(late)); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("ToObjectID of an id in 2106 error = %v, want ErrTimestampOverflow", err)
+	}
+}
+
+func TestObjectIDOrder(t *testing.T) {
+	g := NewGenerator()
+	prev, _ := ToObjectID(knownID)
+	for s := 1; s < 100; s++ {
+		id, _ := g.GenerateAt(testTime.Add(time.Duration(s) * 1500 * time.Millisecond))
+		oid, err := ToObjectID(PushID(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bytes.Compare(prev[:], oid[:]) >= 0 {
+			t.Fatalf("ObjectID %x of %q does not sort after %x", oid, id, prev)
+		}
+
+		prev = oid
+	}
+}
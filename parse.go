@@ -0,0 +1,220 @@
+package pushid
+
+import (
+	"fmt"
+	"time"
+)
+
+// Validate returns an error describing why id is not a well-formed push id: either its length is not 20
+// or the character at the first offending index is not one of PUSH_CHARS.
+func Validate(id string) error {
+	return validate(id, idLen)
+}
+
+// Validates id as a push id of length n.
+func validate(id string, n int) error {
+	if len(id) != n {
+		return fmt.Errorf("%w: push id %q is %d characters, should be %d", ErrInvalidLength, id, len(id), n)
+	}
+
+	if i := invalidIndex(id); i >= 0 {
+		return &CharacterError{ID: id, Index: i, Char: id[i]}
+	}
+
+	return nil
+}
+
+// IsValid reports whether id is a well-formed push id. Unlike Validate it never allocates, so it is cheap
+// enough to call on every request.
+func IsValid(id string) bool {
+	return len(id) == idLen && invalidIndex(id) < 0
+}
+
+// Returns the index of the first byte of id that is not in PUSH_CHARS, or -1. The check is per byte, so
+// any multibyte UTF-8 sequence is rejected.
+func invalidIndex(id string) int {
+	for i := 0; i < len(id); i++ {
+		if pushCharIndex[id[i]] < 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Parsed holds the components of a push id. The field docs describe the default Millisecond layout; see
+// Resolution.Parse for the others.
+type Parsed struct {
+	// Time encoded in the first 8 characters, in UTC and at millisecond precision.
+	Time time.Time
+
+	// The same timestamp as the raw 48-bit count of milliseconds since the Unix epoch.
+	Millis int64
+
+	// The 72 random bits of the last 12 characters, packed 6 bits per character with the most
+	// significant bits first. This is the same layout WithEntropy reads.
+	Random [9]byte
+
+	// The id that was parsed.
+	Raw string
+}
+
+// Parse validates id and decodes it into its timestamp and random components in one pass. It only accepts
+// the default Millisecond layout; ids of the other resolutions have other lengths and are rejected with
+// ErrInvalidLength. Parse those with Resolution.Parse.
+func Parse(id string) (Parsed, error) {
+	return Millisecond.Parse(id)
+}
+
+// ParseDescending is Parse for ids generated with WithDescending: it complements every character back
+// before decoding, so Time and Random hold the true timestamp and random bits. Raw is id as given.
+func ParseDescending(id string) (Parsed, error) {
+	if err := Validate(id); err != nil {
+		return Parsed{}, err
+	}
+
+	var asc [idLen]byte
+	for i := 0; i < idLen; i++ {
+		asc[i] = PUSH_CHARS[radix-1-pushCharIndex[id[i]]]
+	}
+
+	p, err := Parse(string(asc[:]))
+	if err != nil {
+		return Parsed{}, err
+	}
+
+	p.Raw = id
+	return p, nil
+}
+
+// Timestamp returns the time encoded in the first 8 characters of id, in UTC and at millisecond precision.
+// Like Parse it only accepts the Millisecond layout; use Resolution.Timestamp for the others.
+func Timestamp(id string) (time.Time, error) {
+	p, err := Parse(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return p.Time, nil
+}
+
+// TimestampPrefix validates id and returns its first 8 characters, the encoded timestamp. All ids generated
+// in the same millisecond share this prefix, so it can key prefix scans or groups by millisecond, and
+// MinIDForTime and MaxIDForTime bound the ids that start with it. Inputs that are not complete push ids are
+// rejected even if they start with 8 valid characters.
+func TimestampPrefix(id string) (string, error) {
+	if err := Validate(id); err != nil {
+		return "", err
+	}
+
+	return id[:timeChars], nil
+}
+
+// Since returns the time elapsed between the creation of a and of b, that is b's timestamp minus a's. IDs
+// only carry millisecond precision and so does the result, which is negative if a is the later id.
+func Since(a, b string) (time.Duration, error) {
+	ta, err := Timestamp(a)
+	if err != nil {
+		return 0, err
+	}
+
+	tb, err := Timestamp(b)
+	if err != nil {
+		return 0, err
+	}
+
+	return tb.Sub(ta), nil
+}
+
+// Age returns how long ago id was created: the current time minus its timestamp. It is AgeAt with
+// time.Now.
+func Age(id string) (time.Duration, error) {
+	return AgeAt(id, time.Now())
+}
+
+// AgeAt returns how old id was at now: now minus its timestamp. An id from after now, for example one
+// generated on a host whose clock runs ahead, has a negative age rather than an error.
+func AgeAt(id string, now time.Time) (time.Duration, error) {
+	t, err := Timestamp(id)
+	if err != nil {
+		return 0, err
+	}
+
+	return now.Sub(t), nil
+}
+
+// Decode returns the timestamp of id and its 72 random bits as a 9-byte slice, packed as in Parsed.Random.
+// IDs with the same random suffix decode to identical slices.
+func Decode(id string) (time.Time, []byte, error) {
+	p, err := Parse(id)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+
+	return p.Time, p.Random[:], nil
+}
+
+// Encode builds a push id from a timestamp and exactly 9 bytes (72 bits) of randomness, packed as in
+// Parsed.Random. It is the inverse of Decode.
+func Encode(t time.Time, random []byte) (string, error) {
+	if len(random) != 9 {
+		return "", fmt.Errorf("%w: random is %d bytes, should be 9", ErrInvalidLength, len(random))
+	}
+
+	millis := t.UnixMilli()
+	if err := Millisecond.check(millis); err != nil {
+		return "", err
+	}
+
+	var id [idLen]byte
+	for i := timeChars - 1; i >= 0; i-- {
+		id[i] = PUSH_CHARS[millis%radix]
+		millis /= radix
+	}
+
+	chars := unpackRandChars([9]byte(random))
+	for i := 0; i < randChars; i++ {
+		id[timeChars+i] = PUSH_CHARS[chars[i]]
+	}
+
+	return string(id[:]), nil
+}
+
+// MinIDForTime returns the smallest push id with the timestamp t: its timestamp characters followed by
+// twelve '-'. Together with MaxIDForTime it bounds a range scan, [MinIDForTime(start), MaxIDForTime(end)].
+func MinIDForTime(t time.Time) (string, error) {
+	return Encode(t, make([]byte, 9))
+}
+
+// MaxIDForTime returns the largest push id with the timestamp t: its timestamp characters followed by
+// twelve 'z'.
+func MaxIDForTime(t time.Time) (string, error) {
+	return Encode(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+}
+
+// Packs 12 characters of 6 bits into 9 bytes, most significant bits first.
+func packRandChars(chars [randChars]int8) [9]byte {
+	var b [9]byte
+	for i := 0; i < 3; i++ {
+		c0, c1, c2, c3 := byte(chars[i*4]), byte(chars[i*4+1]), byte(chars[i*4+2]), byte(chars[i*4+3])
+		b[i*3] = c0<<2 | c1>>4
+		b[i*3+1] = c1<<4 | c2>>2
+		b[i*3+2] = c2<<6 | c3
+	}
+
+	return b
+}
+
+// Unpacks 9 bytes into 12 characters of 6 bits, most significant bits first.
+func unpackRandChars(b [9]byte) [randChars]int8 {
+	var chars [randChars]int8
+	for i := 0; i < 3; i++ {
+		b0, b1, b2 := b[i*3], b[i*3+1], b[i*3+2]
+		chars[i*4] = int8(b0 >> 2)
+		chars[i*4+1] = int8((b0&0x03)<<4 | b1>>4)
+		chars[i*4+2] = int8((b1&0x0f)<<2 | b2>>6)
+		chars[i*4+3] = int8(b2 & 0x3f)
+	}
+
+	return chars
+}
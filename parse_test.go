@@ -0,0 +1,246 @@
+package pushid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		id   string
+		want error
+	}{
+		{"-JhLeOlGIEjaIOFHR0xd", nil},
+		{string(Nil), nil},
+		{string(Max), nil},
+		{"", ErrInvalidLength},
+		{"-JhLeOlGIEjaIOFHR0x", ErrInvalidLength},
+		{"-JhLeOlGIEjaIOFHR0xdd", ErrInvalidLength},
+		{"-JhLeOlGIEjaIOFHR0x+", ErrInvalidCharacter},
+		{"-JhLeOlGIEjaIOFHR0\xc3\xa9", ErrInvalidCharacter},
+	} {
+		err := Validate(tt.id)
+		if !errors.Is(err, tt.want) || (tt.want == nil) != (err == nil) {
+			t.Errorf("Validate(%q) = %v, want %v", tt.id, err, tt.want)
+		}
+
+		if got := IsValid(tt.id); got != (tt.want == nil) {
+			t.Errorf("IsValid(%q) = %v, want %v", tt.id, got, tt.want == nil)
+		}
+	}
+
+	var charErr *CharacterError
+	if err := Validate("-JhLeOlGIEj+IOFHR0xd"); !errors.As(err, &charErr) || charErr.Index != 11 || charErr.Char != '+' {
+		t.Errorf("Validate error = %#v, want a CharacterError for '+' at index 11", err)
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	for _, tt := range []struct {
+		id   string
+		want time.Time
+	}{
+		{"-------0------------", time.UnixMilli(1).UTC()},
+		{"-JhLeOlGIEjaIOFHR0xd", time.Date(2015, 2, 4, 22, 15, 31, 153e6, time.UTC)},
+		{"zzzzzzzz------------", time.UnixMilli(1<<48 - 1).UTC()},
+	} {
+		got, err := Timestamp(tt.id)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("Timestamp(%q) = %v, %v, want %v", tt.id, got, err, tt.want)
+		}
+	}
+
+	for _, id := range []string{"", "-JhLeOlGIEjaIOFHR0x", "-JhLeOlG+EjaIOFHR0xd"} {
+		if _, err := Timestamp(id); err == nil {
+			t.Errorf("Timestamp(%q) returned no error", id)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 678e6, time.UTC)
+	id, err := NewGenerator(WithClock(fixedClock(at)), WithEntropy(byteReader(0xa5))).Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Parse(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	random := [9]byte{0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5, 0xa5}
+	if !p.Time.Equal(at) || p.Random != random || p.Millis != at.UnixMilli() || p.Raw != id {
+		t.Errorf("Parse(%q) = %+v, want time %v, random %x and raw %q", id, p, at, random, id)
+	}
+
+	if _, err := Parse("short"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Parse(short) error = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestDecodeEncodeRoundTrip(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		id, _ := Generate()
+
+		ts, random, err := Decode(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, err := Encode(ts, random); err != nil || got != id {
+			t.Errorf("Encode(Decode(%q)) = %q, %v", id, got, err)
+		}
+	}
+
+	for _, n := range []int{0, 8, 10} {
+		if _, err := Encode(time.Now(), make([]byte, n)); !errors.Is(err, ErrInvalidLength) {
+			t.Errorf("Encode with %d random bytes error = %v, want ErrInvalidLength", n, err)
+		}
+	}
+
+	if _, err := Encode(time.UnixMilli(-1), make([]byte, 9)); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Encode before the epoch error = %v, want ErrTimestampOverflow", err)
+	}
+}
+
+func TestMinMaxIDForTime(t *testing.T) {
+	base := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for ms := 0; ms < 3; ms++ {
+		at := base.Add(time.Duration(ms) * time.Millisecond)
+		lo, err := MinIDForTime(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hi, err := MaxIDForTime(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 100; i++ {
+			id, _ := NewGenerator().GenerateAt(at)
+			if id < lo || id > hi {
+				t.Fatalf("id %q at %v is outside [%q, %q]", id, at, lo, hi)
+			}
+		}
+
+		if next, _ := MinIDForTime(at.Add(time.Millisecond)); next <= hi {
+			t.Errorf("MinIDForTime of the next millisecond %q does not sort after MaxIDForTime %q", next, hi)
+		}
+	}
+}
+
+func TestSince(t *testing.T) {
+	g := NewGenerator()
+	a, _ := g.GenerateAt(testTime)
+	b, _ := g.GenerateAt(testTime.Add(1500 * time.Millisecond))
+
+	if d, err := Since(a, b); err != nil || d != 1500*time.Millisecond {
+		t.Errorf("Since(a, b) = %v, %v, want 1.5s", d, err)
+	}
+
+	if d, err := Since(b, a); err != nil || d != -1500*time.Millisecond {
+		t.Errorf("Since(b, a) = %v, %v, want -1.5s", d, err)
+	}
+
+	if _, err := Since("bad", b); err == nil {
+		t.Error("Since with an invalid id returned no error")
+	}
+}
+
+func TestTimestampPrefix(t *testing.T) {
+	g := NewGenerator(WithClock(steppedClock(testTime, testTime, testTime.Add(time.Millisecond))))
+	a, _ := g.Generate()
+	b, _ := g.Generate()
+	c, _ := g.Generate()
+
+	pa, err := TimestampPrefix(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pb, _ := TimestampPrefix(b); pb != pa {
+		t.Errorf("ids %q and %q from the same millisecond have prefixes %q and %q", a, b, pa, pb)
+	}
+
+	if pc, _ := TimestampPrefix(c); pc == pa {
+		t.Errorf("ids %q and %q from different milliseconds share the prefix %q", a, c, pa)
+	}
+
+	if _, err := TimestampPrefix(a[:timeChars]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("TimestampPrefix of a bare prefix error = %v, want ErrInvalidLength", err)
+	}
+}
+
+func TestAgeAt(t *testing.T) {
+	id, _ := NewGenerator().GenerateAt(testTime)
+
+	if d, err := AgeAt(id, testTime.Add(time.Minute)); err != nil || d != time.Minute {
+		t.Errorf("AgeAt a minute later = %v, %v, want 1m", d, err)
+	}
+
+	if d, err := AgeAt(id, testTime.Add(-time.Second)); err != nil || d != -time.Second {
+		t.Errorf("AgeAt a second earlier = %v, %v, want -1s", d, err)
+	}
+
+	if _, err := Age("bad"); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Age(bad) error = %v, want ErrInvalidLength", err)
+	}
+
+	if d, err := PushID(id).Age(); err != nil || d <= 0 {
+		t.Errorf("PushID.Age() = %v, %v, want a positive duration", d, err)
+	}
+}
+
+func TestCharIndexAndCharAt(t *testing.T) {
+	for i := 0; i < len(PUSH_CHARS); i++ {
+		if got, ok := CharIndex(PUSH_CHARS[i]); !ok || got != i {
+			t.Errorf("CharIndex(%q) = %d, %v, want %d", PUSH_CHARS[i], got, ok, i)
+		}
+
+		if got, ok := CharAt(i); !ok || got != PUSH_CHARS[i] {
+			t.Errorf("CharAt(%d) = %q, %v, want %q", i, got, ok, PUSH_CHARS[i])
+		}
+	}
+
+	for _, c := range []byte{0, ' ', '+', '/', '.', '~', 0x80, 0xff} {
+		if got, ok := CharIndex(c); ok || got != -1 {
+			t.Errorf("CharIndex(%q) = %d, %v, want -1, false", c, got, ok)
+		}
+	}
+
+	for _, i := range []int{-1, radix, 1000} {
+		if _, ok := CharAt(i); ok {
+			t.Errorf("CharAt(%d) reported ok", i)
+		}
+	}
+}
+
+func TestErrorsIs(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"length", Validate("x"), ErrInvalidLength},
+		{"character", Validate("-JhLeOlGIEjaIOFHR0x+"), ErrInvalidCharacter},
+		{"alphabet", func() error { _, err := NewGeneratorWithAlphabet("ab"); return err }(), ErrInvalidAlphabet},
+		{"timestamp", func() error { _, err := GenerateAt(time.UnixMilli(-1)); return err }(), ErrTimestampOverflow},
+		{"successor", func() error { _, err := NextID(string(Max)); return err }(), ErrTimestampOverflow},
+		{"prefix", func() error { _, err := GenerateWithPrefix("Usr"); return err }(), ErrInvalidPrefix},
+	} {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: error %v does not match %v", tt.name, tt.err, tt.want)
+		}
+	}
+}
+
+func BenchmarkIsValid(b *testing.B) {
+	id, _ := Generate()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		IsValid(id)
+	}
+}
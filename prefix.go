@@ -0,0 +1,118 @@
+package pushid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Separates a type prefix from the push id in prefixed ids.
+const prefixSeparator = '_'
+
+// GenerateWithPrefix returns a push id from the default Generator preceded by prefix and an underscore,
+// like "usr_-MxyzABC...". See Generator.GenerateWithPrefix.
+func GenerateWithPrefix(prefix string) (string, error) {
+	return defaultGenerator.GenerateWithPrefix(prefix)
+}
+
+// GenerateWithPrefix returns a push id preceded by prefix and an underscore, like "usr_-MxyzABC...", for
+// self-describing keys. prefix must be lowercase ASCII letters, optionally terminated by the underscore:
+// "usr" and "usr_" give the same ids. An empty prefix returns a plain push id. Ids with the same prefix
+// still sort chronologically.
+//
+// ParsePrefixed only reads 20-character ids in PUSH_CHARS, so g must have the Millisecond resolution and
+// the default alphabet.
+func (g *Generator) GenerateWithPrefix(prefix string) (string, error) {
+	prefix, err := normalizePrefix(prefix)
+	if err != nil {
+		return "", err
+	}
+
+	if g.resolution != Millisecond {
+		return "", fmt.Errorf("Prefixed ids need the millisecond resolution, not the %s resolution of this Generator", g.resolution)
+	}
+
+	if g.alphabet != "" && g.alphabet != PUSH_CHARS {
+		return "", fmt.Errorf("Prefixed ids need PUSH_CHARS, not the custom alphabet of this Generator")
+	}
+
+	id, err := g.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	if prefix == "" {
+		return id, nil
+	}
+
+	return prefix + string(prefixSeparator) + id, nil
+}
+
+// ParsePrefixed splits s, as returned by GenerateWithPrefix, into its prefix, without the underscore, and
+// its push id. A plain push id parses with an empty prefix. Since push ids may themselves contain
+// underscores, s is split 20 characters from the end rather than at the first underscore.
+func ParsePrefixed(s string) (prefix string, id PushID, err error) {
+	rest := s
+	if len(s) > idLen {
+		if s[len(s)-idLen-1] != prefixSeparator {
+			return "", "", fmt.Errorf("%w: %q does not end in an underscore and a %d-character push id", ErrInvalidPrefix, s, idLen)
+		}
+
+		prefix = s[:len(s)-idLen-1]
+		if prefix == "" {
+			return "", "", fmt.Errorf("%w: %q has an empty prefix before the underscore", ErrInvalidPrefix, s)
+		}
+
+		if err := validatePrefix(prefix); err != nil {
+			return "", "", err
+		}
+
+		rest = s[len(s)-idLen:]
+	}
+
+	id, err = FromString(rest)
+	if err != nil {
+		return "", "", err
+	}
+
+	return prefix, id, nil
+}
+
+// StripPrefix returns the push id of s, as returned by GenerateWithPrefix, without its prefix.
+func StripPrefix(s string) (PushID, error) {
+	_, id, err := ParsePrefixed(s)
+	return id, err
+}
+
+// HasPrefix reports whether s is a valid push id with the type prefix prefix, given with or without the
+// terminating underscore as for GenerateWithPrefix. An empty prefix matches plain push ids only.
+func HasPrefix(s, prefix string) bool {
+	want, err := normalizePrefix(prefix)
+	if err != nil {
+		return false
+	}
+
+	p, _, err := ParsePrefixed(s)
+	return err == nil && p == want
+}
+
+// Returns prefix without its terminating underscore, if it has one, or an error wrapping ErrInvalidPrefix
+// unless the rest is valid for validatePrefix. A lone underscore is rejected rather than read as empty.
+func normalizePrefix(prefix string) (string, error) {
+	trimmed := strings.TrimSuffix(prefix, string(prefixSeparator))
+	if trimmed == "" && prefix != "" {
+		return "", fmt.Errorf("%w: %q has an empty prefix before the underscore", ErrInvalidPrefix, prefix)
+	}
+
+	return trimmed, validatePrefix(trimmed)
+}
+
+// Returns an error wrapping ErrInvalidPrefix unless prefix is empty or lowercase ASCII letters.
+func validatePrefix(prefix string) error {
+	for i := 0; i < len(prefix); i++ {
+		if c := prefix[i]; c < 'a' || c > 'z' {
+			return fmt.Errorf("%w: %q at index %d of %q, should be a lowercase ASCII letter", ErrInvalidPrefix, c, i, prefix)
+		}
+	}
+
+	return nil
+}
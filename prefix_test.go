@@ -0,0 +1,91 @@
+package pushid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateWithPrefix(t *testing.T) {
+	s, err := GenerateWithPrefix("usr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prefix, id, err := ParsePrefixed(s)
+	if err != nil || prefix != "usr" || s != "usr_"+string(id) {
+		t.Errorf("ParsePrefixed(%q) = %q, %q, %v", s, prefix, id, err)
+	}
+
+	if got, err := StripPrefix(s); err != nil || got != id {
+		t.Errorf("StripPrefix(%q) = %q, %v, want %q", s, got, err, id)
+	}
+
+	if !HasPrefix(s, "usr") || HasPrefix(s, "org") || HasPrefix(s, "") {
+		t.Errorf("HasPrefix of %q matches the wrong prefixes", s)
+	}
+
+	if s, err := GenerateWithPrefix(""); err != nil || !IsValid(s) {
+		t.Errorf("GenerateWithPrefix with an empty prefix = %q, %v, want a plain push id", s, err)
+	}
+
+	// The terminating underscore may be given.
+	if s, err := GenerateWithPrefix("usr_"); err != nil || !HasPrefix(s, "usr") || !HasPrefix(s, "usr_") || strings.HasPrefix(s, "usr__") {
+		t.Errorf("GenerateWithPrefix(%q) = %q, %v, want the prefix usr", "usr_", s, err)
+	}
+
+	for _, prefix := range []string{"Usr", "us_r", "usr1", "ü", "_", "usr__"} {
+		if _, err := GenerateWithPrefix(prefix); !errors.Is(err, ErrInvalidPrefix) {
+			t.Errorf("GenerateWithPrefix(%q) error = %v, want ErrInvalidPrefix", prefix, err)
+		}
+	}
+
+	// ParsePrefixed only reads Millisecond ids in PUSH_CHARS.
+	if s, err := NewGenerator(WithResolution(Second)).GenerateWithPrefix("usr"); err == nil {
+		t.Errorf("GenerateWithPrefix of a Second Generator = %q, want an error", s)
+	}
+
+	g, err := NewGeneratorWithAlphabet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := g.GenerateWithPrefix("usr"); err == nil {
+		t.Errorf("GenerateWithPrefix with a custom alphabet = %q, want an error", s)
+	}
+}
+
+func TestParsePrefixed(t *testing.T) {
+	for _, tt := range []struct {
+		s      string
+		prefix string
+		id     PushID
+	}{
+		{string(knownID), "", knownID},
+		{"usr_" + string(knownID), "usr", knownID},
+		// Push ids may contain underscores themselves, so the split is by length.
+		{"org_-JhLeOlGIEja_OFHR0xd", "org", "-JhLeOlGIEja_OFHR0xd"},
+		{"a__JhLeOlGIEjaIOFHR0xd", "a", "_JhLeOlGIEjaIOFHR0xd"},
+	} {
+		prefix, id, err := ParsePrefixed(tt.s)
+		if err != nil || prefix != tt.prefix || id != tt.id {
+			t.Errorf("ParsePrefixed(%q) = %q, %q, %v, want %q, %q", tt.s, prefix, id, err, tt.prefix, tt.id)
+		}
+	}
+
+	for _, tt := range []struct {
+		s    string
+		want error
+	}{
+		{"_" + string(knownID), ErrInvalidPrefix},
+		{"usr-" + string(knownID), ErrInvalidPrefix},
+		{"u_r_" + string(knownID), ErrInvalidPrefix},
+		{"usr_bad", ErrInvalidLength},
+		{"usr_-JhLeOlGIEjaIOFHR0x+", ErrInvalidCharacter},
+		{"", ErrInvalidLength},
+	} {
+		if _, _, err := ParsePrefixed(tt.s); !errors.Is(err, tt.want) {
+			t.Errorf("ParsePrefixed(%q) error = %v, want %v", tt.s, err, tt.want)
+		}
+	}
+}
@@ -15,33 +15,59 @@
 package pushid
 
 import (
-	"fmt"
-	"math"
-	"math/rand"
-	"strings"
 	"time"
 )
 
-var (
-	// Timestamp of last push, used to prevent local collisions if you push twice in one ms.
-	lastPushTime int64
-
-	// We generate 72-bits of randomness which get turned into 12 characters and appended to the
-	// timestamp to prevent collisions with other clients. We store the last characters we
-	// generated because in the event of a collision, we'll use those same characters except
-	// "incremented" by one.
-	lastRandChars []int8
-)
-
 const (
 	// Modeled after base64 web-safe chars, but ordered by ASCII.
 	PUSH_CHARS string = "-0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz"
 )
 
+const (
+	// Base of the encoding, the number of PUSH_CHARS: every character carries 6 bits. Untyped, unlike
+	// len(PUSH_CHARS), so it mixes with the int8 and int64 arithmetic of the encoders.
+	radix = 64
+
+	// Timestamp and random characters of a push id with the default resolution, and its length.
+	timeChars = 8
+	randChars = 12
+	idLen     = timeChars + randChars
+)
+
+// Maps every byte to its index in PUSH_CHARS, or -1 for bytes outside the alphabet.
+var pushCharIndex [256]int8
+
 func init() {
-	lastRandChars = make([]int8, 12, 12)
+	for i := range pushCharIndex {
+		pushCharIndex[i] = -1
+	}
+
+	for i := 0; i < len(PUSH_CHARS); i++ {
+		pushCharIndex[PUSH_CHARS[i]] = int8(i)
+	}
+}
+
+// CharIndex returns the 0-63 value of c in PUSH_CHARS, and false if c is not one of them.
+func CharIndex(c byte) (int, bool) {
+	i := pushCharIndex[c]
+	return int(i), i >= 0
+}
+
+// CharAt returns the character of PUSH_CHARS with the value i, and false unless 0 <= i < 64.
+func CharAt(i int) (byte, bool) {
+	if i < 0 || i >= len(PUSH_CHARS) {
+		return 0, false
+	}
+
+	return PUSH_CHARS[i], true
 }
 
+// Shared by the package-level functions.
+var (
+	defaultGenerator           = NewGenerator()
+	defaultDescendingGenerator = NewGenerator(WithDescending())
+)
+
 // Generate returns a best-effort unique push id.
 //
 // Taken from: https://www.firebase.com/blog/2015-02-11-firebase-unique-identifiers.html
@@ -56,44 +82,53 @@ func init() {
 // >  To turn our 120 bits of information (timestamp + randomness) into an ID that can be used as a Firebase key,
 // >  we basically base64 encode it into ASCII characters, but we use a modified base64 alphabet that ensures the
 // >  IDs will still sort correctly when ordered lexicographically (since Firebase keys are ordered lexicographically).
+//
+// Generate is safe for concurrent use by multiple goroutines. Like Generator.Generate it never goes
+// backwards when the clock does.
 func Generate() (string, error) {
-	now := time.Now().UTC().UnixNano() / 1000000
-	duplicateTime := now == lastPushTime
-	lastPushTime = now
-
-	timeStampChars := make([]string, 8, 8)
-	for i := 7; i >= 0; i-- {
-		pcIndex := int64(math.Mod(float64(now), 64.0))
-		timeStampChars[i] = string(PUSH_CHARS[pcIndex])
-		now = int64(math.Floor(float64(now) / 64.0))
-	}
+	return defaultGenerator.Generate()
+}
 
-	if now != 0 {
-		return "", fmt.Errorf("We should have converted the entire timestamp.")
+// MustGenerate is like Generate but panics if an id cannot be generated. It simplifies safe initialization
+// of global variables holding push ids.
+func MustGenerate() string {
+	id, err := Generate()
+	if err != nil {
+		panic("pushid: Generate: " + err.Error())
 	}
 
-	id := strings.Join(timeStampChars, "")
+	return id
+}
+
+// Reset drops the monotonic state of the default generators, those of Generate and of GenerateDescending.
+// See Generator.Reset.
+func Reset() {
+	defaultGenerator.Reset()
+	defaultDescendingGenerator.Reset()
+}
 
-	if !duplicateTime {
-		for i := 0; i < 12; i++ {
-			lastRandChars[i] = int8(math.Floor(rand.Float64() * 64.0))
-		}
-	} else {
-		var i int
-		for i = 11; i >= 0 && lastRandChars[i] == 63; i-- {
-			lastRandChars[i] = 0
-		}
+// GenerateAt returns a push id whose timestamp is t from the default generator. See Generator.GenerateAt.
+func GenerateAt(t time.Time) (string, error) {
+	return defaultGenerator.GenerateAt(t)
+}
 
-		lastRandChars[i]++
-	}
+// Append generates a push id from the default generator and appends it to dst. See Generator.Append.
+func Append(dst []byte) ([]byte, error) {
+	return defaultGenerator.Append(dst)
+}
 
-	for i := 0; i < 12; i++ {
-		id = fmt.Sprintf("%s%s", id, string(PUSH_CHARS[lastRandChars[i]]))
-	}
+// GenerateInto writes a push id from the default generator into dst. See Generator.GenerateInto.
+func GenerateInto(dst []byte) (int, error) {
+	return defaultGenerator.GenerateInto(dst)
+}
 
-	if len(id) != 20 {
-		return "", fmt.Errorf("Length should be 20")
-	}
+// GenerateN returns n push ids in strictly increasing order from the default generator.
+func GenerateN(n int) ([]string, error) {
+	return defaultGenerator.GenerateN(n)
+}
 
-	return id, nil
+// GenerateDescending returns a push id that sorts before every id previously returned by it, for
+// newest-first listings. See WithDescending.
+func GenerateDescending() (string, error) {
+	return defaultDescendingGenerator.Generate()
 }
@@ -0,0 +1,57 @@
+package pushid
+
+import (
+	"testing"
+)
+
+func TestLayoutConstants(t *testing.T) {
+	if idLen != 20 || timeChars != 8 || randChars != 12 || radix != len(PUSH_CHARS) {
+		t.Fatalf("layout is %d+%d=%d characters in base %d, want 8+12=20 in base %d", timeChars, randChars, idLen, radix, len(PUSH_CHARS))
+	}
+
+	id, err := Generate()
+	if err != nil || len(id) != idLen {
+		t.Errorf("Generate() = %q, %v, want %d characters", id, err, idLen)
+	}
+
+	if p, err := TimestampPrefix(id); err != nil || len(p) != timeChars {
+		t.Errorf("TimestampPrefix(%q) = %q, %v, want %d characters", id, p, err, timeChars)
+	}
+
+	s, err := GenerateWithPrefix("usr")
+	if _, got, err2 := ParsePrefixed(s); err != nil || err2 != nil || len(got) != idLen {
+		t.Errorf("ParsePrefixed(%q) = %q, %v, want a %d-character id", s, got, err2, idLen)
+	}
+}
+
+func FuzzValidate(f *testing.F) {
+	for _, seed := range []string{
+		"-JhLeOlGIEjaIOFHR0xd",
+		string(Nil),
+		string(Max),
+		"",
+		"-JhLeOlGIEjaIOFHR0x",
+		"-JhLeOlGIEjaIOFHR0xdd",
+		"-JhLeOlGIEjaIOFHR0x+",
+		"-JhLeOlGIEjaIOFHR0\xff\xfe",
+		"-JhLeOlGIEjaIOFHR0é",
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		id := string(data)
+		err := Validate(id)
+		if valid := IsValid(id); valid != (err == nil) {
+			t.Fatalf("IsValid(%q) = %v, but Validate returned %v", id, valid, err)
+		}
+
+		if err != nil {
+			return
+		}
+
+		if _, err := Timestamp(id); err != nil {
+			t.Fatalf("Validate accepted %q, but Timestamp failed: %v", id, err)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+// Package pushidbson encodes push ids in BSON for MongoDB. It lives in its own module so that only its
+// users depend on go.mongodb.org/mongo-driver, which the pushid package does not.
+//
+// Methods cannot be added to pushid.PushID from outside its package, so the encoding is a codec for a
+// bson registry rather than bson.ValueMarshaler and bson.ValueUnmarshaler. Pass NewRegistry, or a registry
+// given to Register, to the client or to a bson.Encoder and bson.Decoder:
+//
+//	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetRegistry(pushidbson.NewRegistry()))
+package pushidbson
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/zerklabs/pushid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+var pushIDType = reflect.TypeOf(pushid.PushID(""))
+
+// Codec is the bsoncodec.ValueEncoder and bsoncodec.ValueDecoder of pushid.PushID. It encodes an id as a
+// BSON string, and the zero value as null.
+//
+// It decodes a BSON string holding a valid push id, binary data holding the 15-byte form of MarshalBinary,
+// or null for the zero value.
+type Codec struct{}
+
+// EncodeValue implements bsoncodec.ValueEncoder.
+func (Codec) EncodeValue(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != pushIDType {
+		return bsoncodec.ValueEncoderError{Name: "pushidbson.Codec", Types: []reflect.Type{pushIDType}, Received: val}
+	}
+
+	p := val.Interface().(pushid.PushID)
+	if p.IsZero() {
+		return vw.WriteNull()
+	}
+
+	if err := pushid.Validate(string(p)); err != nil {
+		return err
+	}
+
+	return vw.WriteString(string(p))
+}
+
+// DecodeValue implements bsoncodec.ValueDecoder.
+func (Codec) DecodeValue(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != pushIDType {
+		return bsoncodec.ValueDecoderError{Name: "pushidbson.Codec", Types: []reflect.Type{pushIDType}, Received: val}
+	}
+
+	var p pushid.PushID
+	switch t := vr.Type(); t {
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+	case bsontype.String:
+		s, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+
+		if p, err = pushid.FromString(s); err != nil {
+			return err
+		}
+	case bsontype.Binary:
+		b, _, err := vr.ReadBinary()
+		if err != nil {
+			return err
+		}
+
+		if err := p.UnmarshalBinary(b); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("Cannot decode BSON %s into PushID", t)
+	}
+
+	val.Set(reflect.ValueOf(p))
+	return nil
+}
+
+// Register registers Codec on r for pushid.PushID values.
+func Register(r *bsoncodec.Registry) {
+	r.RegisterTypeEncoder(pushIDType, Codec{})
+	r.RegisterTypeDecoder(pushIDType, Codec{})
+}
+
+// NewRegistry returns the default bson registry with Codec registered.
+func NewRegistry() *bsoncodec.Registry {
+	r := bson.NewRegistry()
+	Register(r)
+	return r
+}
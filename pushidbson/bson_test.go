@@ -0,0 +1,94 @@
+package pushidbson
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zerklabs/pushid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+)
+
+const knownID pushid.PushID = "-JhLeOlGIEjaIOFHR0xd"
+
+type record struct {
+	ID     pushid.PushID `bson:"_id"`
+	Parent pushid.PushID `bson:"parent"`
+}
+
+// Encodes v as a BSON document with NewRegistry.
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	vw, err := bsonrw.NewBSONValueWriter(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := bson.NewEncoder(vw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := enc.SetRegistry(NewRegistry()); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decodes the BSON document data into v with NewRegistry.
+func unmarshal(data []byte, v interface{}) error {
+	dec, err := bson.NewDecoder(bsonrw.NewBSONDocumentReader(data))
+	if err != nil {
+		return err
+	}
+
+	if err := dec.SetRegistry(NewRegistry()); err != nil {
+		return err
+	}
+
+	return dec.Decode(v)
+}
+
+func TestBSONRoundTrip(t *testing.T) {
+	in := record{ID: knownID}
+	data, err := marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw bson.Raw = data
+	if got := raw.Lookup("_id").StringValue(); got != string(knownID) {
+		t.Errorf("_id encoded as %q, want the string %q", got, knownID)
+	}
+
+	if got := raw.Lookup("parent"); got.Type != bson.TypeNull {
+		t.Errorf("zero value parent encoded as %s, want null", got.Type)
+	}
+
+	var out record
+	if err := unmarshal(data, &out); err != nil || out != in {
+		t.Errorf("bson round trip = %+v, %v, want %+v", out, err, in)
+	}
+
+	b := knownID.Bytes()
+	data, _ = bson.Marshal(bson.M{"_id": b[:]})
+	if err := unmarshal(data, &out); err != nil || out.ID != knownID {
+		t.Errorf("Unmarshal of the binary form = %q, %v, want %q", string(out.ID), err, knownID)
+	}
+
+	for _, doc := range []bson.M{{"_id": "bad"}, {"_id": 42}, {"_id": []byte{1, 2, 3}}} {
+		data, _ := bson.Marshal(doc)
+		if err := unmarshal(data, &out); err == nil {
+			t.Errorf("Unmarshal of %v returned no error", doc)
+		}
+	}
+
+	if _, err := marshal(record{ID: "bad"}); err == nil {
+		t.Error("Marshal of an invalid id returned no error")
+	}
+}
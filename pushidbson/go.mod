@@ -0,0 +1,10 @@
+module github.com/zerklabs/pushid/pushidbson
+
+go 1.21
+
+require (
+	github.com/zerklabs/pushid v0.0.0
+	go.mongodb.org/mongo-driver v1.17.10
+)
+
+replace github.com/zerklabs/pushid => ../
@@ -0,0 +1,48 @@
+package pushid
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Encodes b as a big-endian number in the digits of alphabet, left-padded with its zero digit to width
+// characters. With an alphabet in ascending byte order, the strings sort like the numbers.
+func encodeRadix(b []byte, alphabet string, width int) string {
+	n := new(big.Int).SetBytes(b)
+	base := big.NewInt(int64(len(alphabet)))
+
+	out := []byte(strings.Repeat(alphabet[:1], width))
+	digit := new(big.Int)
+	for i := width - 1; i >= 0 && n.Sign() > 0; i-- {
+		n.QuoRem(n, base, digit)
+		out[i] = alphabet[digit.Int64()]
+	}
+
+	return string(out)
+}
+
+// Decodes s, as produced by encodeRadix with the same width, into the big-endian bytes of dst.
+func decodeRadix(dst []byte, s, alphabet string, width int) error {
+	if len(s) != width {
+		return fmt.Errorf("%w: %q is %d characters, should be %d", ErrInvalidLength, s, len(s), width)
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(int64(len(alphabet)))
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(alphabet, s[i])
+		if digit < 0 {
+			return fmt.Errorf("%w: %q at index %d of %q", ErrInvalidCharacter, s[i], i, s)
+		}
+
+		n.Mul(n, base).Add(n, big.NewInt(int64(digit)))
+	}
+
+	if n.BitLen() > len(dst)*8 {
+		return fmt.Errorf("%w: %q does not fit in %d bytes", ErrInvalidLength, s, len(dst))
+	}
+
+	n.FillBytes(dst)
+	return nil
+}
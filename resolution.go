@@ -0,0 +1,169 @@
+package pushid
+
+import (
+	"fmt"
+	"time"
+)
+
+// Resolution is the unit of the timestamp at the start of a push id. It fixes the layout of the id: how
+// many leading characters encode the timestamp and how many random characters follow them.
+//
+// Ids of the same resolution sort chronologically; mixing resolutions in one keyspace is not supported.
+// An id does not record its resolution, but each resolution has a length of its own, so ids of one are
+// rejected where another is expected: the package-level functions only accept Millisecond ids, and
+// Validate, Timestamp and Parse on a Resolution check and decode ids against that layout.
+type Resolution int
+
+const (
+	// Millisecond is the default layout: 8 timestamp characters (48 bits) followed by 12 random
+	// characters (72 bits), 20 in total.
+	Millisecond Resolution = iota
+
+	// Microsecond encodes the timestamp in 10 characters (60 bits) followed by 11 random characters
+	// (66 bits), MicrosecondLen in total: one more than Millisecond ids, so the two cannot be mistaken for
+	// each other. Bursty producers hit the increment path far less often, at the cost of 6 random bits
+	// per id. These ids are not valid for the package-level functions; decode them with
+	// Microsecond.Timestamp.
+	Microsecond
+
+	// Second is the compact layout for sources that never burst: 6 timestamp characters (36 bits, enough
+	// until the year 4147) followed by 12 random characters, SecondLen in total. These ids are not valid
+	// for the package-level functions, which expect 20 characters; decode them with Second.Timestamp.
+	Second
+)
+
+// Lengths of push ids with each resolution.
+const (
+	MillisecondLen = idLen
+	MicrosecondLen = 21
+	SecondLen      = 18
+)
+
+// Length of the longest push id of any resolution, for buffers.
+const maxIDLen = MicrosecondLen
+
+// WithResolution makes the Generator encode timestamps in the unit r instead of milliseconds.
+func WithResolution(r Resolution) Option {
+	return func(g *Generator) {
+		g.resolution = r
+	}
+}
+
+func (r Resolution) String() string {
+	switch r {
+	case Microsecond:
+		return "microsecond"
+	case Second:
+		return "second"
+	default:
+		return "millisecond"
+	}
+}
+
+// Len returns the length of push ids with resolution r.
+func (r Resolution) Len() int {
+	switch r {
+	case Microsecond:
+		return MicrosecondLen
+	case Second:
+		return SecondLen
+	default:
+		return MillisecondLen
+	}
+}
+
+// Validate is the package-level Validate for ids with resolution r: it rejects ids whose length is not
+// r.Len(), such as Second ids checked as Millisecond ones.
+func (r Resolution) Validate(id string) error {
+	return validate(id, r.Len())
+}
+
+// Timestamp returns the time encoded in the timestamp characters of id, an id with resolution r, in UTC and
+// at the precision of r.
+func (r Resolution) Timestamp(id string) (time.Time, error) {
+	p, err := r.Parse(id)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return p.Time, nil
+}
+
+// Parse is the package-level Parse for ids with resolution r. Time is at the precision of r and Millis is
+// that time in whole milliseconds, rounded down. Random holds the random characters packed from the most
+// significant bits, with the bytes past them zero-filled: Microsecond ids have 66 random bits, so the last
+// 6 bits of Random are zero.
+func (r Resolution) Parse(id string) (Parsed, error) {
+	if err := r.Validate(id); err != nil {
+		return Parsed{}, err
+	}
+
+	var ticks int64
+	for i := 0; i < r.timeLen(); i++ {
+		ticks = ticks*radix + int64(pushCharIndex[id[i]])
+	}
+
+	var chars [randChars]int8
+	for i := 0; i < r.randLen(); i++ {
+		chars[i] = pushCharIndex[id[r.timeLen()+i]]
+	}
+
+	t := r.time(ticks)
+	return Parsed{
+		Time:   t,
+		Millis: t.UnixMilli(),
+		Random: packRandChars(chars),
+		Raw:    id,
+	}, nil
+}
+
+// Number of timestamp characters.
+func (r Resolution) timeLen() int {
+	switch r {
+	case Microsecond:
+		return 10
+	case Second:
+		return 6
+	default:
+		return timeChars
+	}
+}
+
+// Number of random characters.
+func (r Resolution) randLen() int {
+	return r.Len() - r.timeLen()
+}
+
+// Converts t to a count of r since the Unix epoch.
+func (r Resolution) ticks(t time.Time) int64 {
+	switch r {
+	case Microsecond:
+		return t.UnixMicro()
+	case Second:
+		return t.Unix()
+	default:
+		return t.UnixMilli()
+	}
+}
+
+// Converts a count of r since the Unix epoch to a UTC time.
+func (r Resolution) time(ticks int64) time.Time {
+	switch r {
+	case Microsecond:
+		return time.UnixMicro(ticks).UTC()
+	case Second:
+		return time.Unix(ticks, 0).UTC()
+	default:
+		return time.UnixMilli(ticks).UTC()
+	}
+}
+
+// Returns an error wrapping ErrTimestampOverflow unless ticks fits in the timestamp characters of r.
+func (r Resolution) check(ticks int64) error {
+	bits := 6 * r.timeLen()
+	if ticks < 0 || ticks >= 1<<bits {
+		return fmt.Errorf("%w: %d, should be within [0, 2^%d) %ss since the Unix epoch", ErrTimestampOverflow, ticks, bits, r)
+	}
+
+	return nil
+}
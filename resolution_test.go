@@ -0,0 +1,117 @@
+package pushid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestResolutionLayouts(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 678912345, time.UTC)
+	for _, tt := range []struct {
+		r    Resolution
+		want time.Time
+	}{
+		{Millisecond, at.Truncate(time.Millisecond)},
+		{Microsecond, at.Truncate(time.Microsecond)},
+		{Second, at.Truncate(time.Second)},
+	} {
+		g := NewGenerator(WithResolution(tt.r))
+		id, err := g.GenerateAt(at)
+		if err != nil || len(id) != tt.r.Len() {
+			t.Fatalf("%s GenerateAt = %q, %v, want %d characters", tt.r, id, err, tt.r.Len())
+		}
+
+		if got, err := tt.r.Timestamp(id); err != nil || !got.Equal(tt.want) {
+			t.Errorf("%s Timestamp(%q) = %v, %v, want %v", tt.r, id, got, err, tt.want)
+		}
+
+		ids := make([]string, 1000)
+		for i := range ids {
+			if ids[i], err = g.Generate(); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tt.r.Validate(ids[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if !IsSorted(ids) {
+			t.Errorf("%s ids are not sorted", tt.r)
+		}
+	}
+
+	second, _ := NewGenerator(WithResolution(Second)).Generate()
+	if err := Validate(second); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Validate of a Second id error = %v, want ErrInvalidLength", err)
+	}
+
+	if _, err := NewGenerator(WithResolution(Second)).GenerateAt(time.Unix(1<<36, 0)); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("Second GenerateAt past 2^36 seconds error = %v, want ErrTimestampOverflow", err)
+	}
+}
+
+func TestMicrosecondFrozenClockIncrements(t *testing.T) {
+	g := NewGenerator(WithResolution(Microsecond), WithClock(fixedClock(testTime)))
+	ids := make([]string, 100)
+	for i := range ids {
+		ids[i], _ = g.Generate()
+	}
+
+	assertStrictlyIncreasing(t, ids)
+}
+
+func TestResolutionParse(t *testing.T) {
+	for _, tt := range []struct {
+		r      Resolution
+		at     time.Time
+		millis int64
+	}{
+		// 999µs is still the millisecond before.
+		{Microsecond, time.UnixMicro(1767323045000999), 1767323045000},
+		{Microsecond, time.UnixMicro(1767323045001000), 1767323045001},
+		{Millisecond, time.UnixMilli(1767323045999), 1767323045999},
+		{Second, time.Unix(1767323045, 0), 1767323045000},
+		{Second, time.Unix(1767323045, 999e6), 1767323045000},
+	} {
+		g := NewGenerator(WithResolution(tt.r), WithEntropy(byteReader(0xff)))
+		id, err := g.GenerateAt(tt.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		p, err := tt.r.Parse(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := tt.r.time(tt.r.ticks(tt.at))
+		if !p.Time.Equal(want) || p.Millis != tt.millis || p.Raw != id {
+			t.Errorf("%s Parse(%q) = %+v, want time %v and millis %d", tt.r, id, p, want, tt.millis)
+		}
+
+		// All the random bits are set, and those past the layout's random characters are zero.
+		wantRandom := [9]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+		if tt.r == Microsecond {
+			wantRandom[8] = 0xc0
+		}
+
+		if p.Random != wantRandom {
+			t.Errorf("%s Parse(%q).Random = %x, want %x", tt.r, id, p.Random, wantRandom)
+		}
+	}
+
+	micro, _ := NewGenerator(WithResolution(Microsecond)).GenerateAt(testTime)
+	if p, err := Parse(micro); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Parse of a Microsecond id = %v, %v, want ErrInvalidLength", p.Time, err)
+	}
+
+	if err := Microsecond.Validate(mustGenerateAt(t, testTime)); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Microsecond Validate of a Millisecond id error = %v, want ErrInvalidLength", err)
+	}
+
+	if _, err := Millisecond.Parse(mustGenerateAt(t, testTime)[:SecondLen]); !errors.Is(err, ErrInvalidLength) {
+		t.Errorf("Millisecond Parse of a Second-length id error = %v, want ErrInvalidLength", err)
+	}
+}
@@ -0,0 +1,48 @@
+package pushid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// ToSnowflake converts id to a 64-bit Snowflake ID: 41 bits of milliseconds since epoch, then 10 node bits
+// and 12 sequence bits taken from the top 22 random bits of id. ids before epoch or more than 2^41
+// milliseconds after it are rejected. Snowflakes of ids in different milliseconds sort like the ids;
+// within a millisecond only the top 22 random bits are compared.
+func ToSnowflake(id PushID, epoch time.Time) (int64, error) {
+	t, err := id.Time()
+	if err != nil {
+		return 0, err
+	}
+
+	delta := t.UnixMilli() - epoch.UnixMilli()
+	if delta < 0 || delta >= 1<<41 {
+		return 0, fmt.Errorf("%w: %s is outside the Snowflake time range from %s", ErrTimestampOverflow, t, epoch)
+	}
+
+	b := id.Bytes()
+	return delta<<22 | int64(binary.BigEndian.Uint32(b[6:10])>>10), nil
+}
+
+// FromSnowflake converts a Snowflake ID back to a push id with the same millisecond, its node and sequence
+// bits as the top 22 random bits and the other 50 random bits zero. v must not be negative, and its time
+// must fit in a push id.
+func FromSnowflake(v int64, epoch time.Time) (PushID, error) {
+	if v < 0 {
+		return "", fmt.Errorf("Invalid Snowflake ID %d, should not be negative", v)
+	}
+
+	millis := epoch.UnixMilli() + v>>22
+	if err := Millisecond.check(millis); err != nil {
+		return "", err
+	}
+
+	var b [15]byte
+	for i := 0; i < 6; i++ {
+		b[i] = byte(millis >> (40 - 8*i))
+	}
+
+	binary.BigEndian.PutUint32(b[6:10], uint32(v&(1<<22-1))<<10)
+	return FromBytes(b), nil
+}
@@ -0,0 +1,75 @@
+package pushid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// Twitter's Snowflake epoch.
+var twitterEpoch = time.UnixMilli(1288834974657)
+
+func TestSnowflakeRoundTrip(t *testing.T) {
+	g := NewGenerator()
+	var prev int64 = -1
+	for ms := 0; ms < 3*60*1000; ms += 997 {
+		id, _ := g.GenerateAt(testTime.Add(time.Duration(ms) * time.Millisecond))
+
+		v, err := ToSnowflake(PushID(id), twitterEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v <= prev {
+			t.Fatalf("Snowflake %d of %q does not sort after %d", v, id, prev)
+		}
+
+		back, err := FromSnowflake(v, twitterEpoch)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The top 22 random bits survive, so the first 3 random characters and most of the 4th do.
+		if ts, _ := back.Time(); ts.UnixMilli() != testTime.UnixMilli()+int64(ms) || back[:timeChars+3] != PushID(id)[:timeChars+3] {
+			t.Errorf("FromSnowflake(ToSnowflake(%q)) = %q", id, back)
+		}
+
+		if again, _ := ToSnowflake(back, twitterEpoch); again != v {
+			t.Errorf("Snowflake %d does not round-trip through %q, got %d", v, back, again)
+		}
+
+		prev = v
+	}
+}
+
+func TestSnowflakeRange(t *testing.T) {
+	for _, at := range []time.Time{twitterEpoch.Add(-time.Millisecond), twitterEpoch.Add(1 << 41 * time.Millisecond)} {
+		id, _ := NewGenerator().GenerateAt(at)
+		if _, err := ToSnowflake(PushID(id), twitterEpoch); !errors.Is(err, ErrTimestampOverflow) {
+			t.Errorf("ToSnowflake of an id at %v error = %v, want ErrTimestampOverflow", at, err)
+		}
+	}
+
+	if id, err := ToSnowflake(PushID(mustGenerateAt(t, twitterEpoch)), twitterEpoch); err != nil || id>>22 != 0 {
+		t.Errorf("ToSnowflake of an id at the epoch = %d, %v, want a zero timestamp", id, err)
+	}
+
+	if _, err := FromSnowflake(-1, twitterEpoch); err == nil {
+		t.Error("FromSnowflake(-1) returned no error")
+	}
+
+	if _, err := FromSnowflake(0, time.UnixMilli(-1)); !errors.Is(err, ErrTimestampOverflow) {
+		t.Errorf("FromSnowflake before the Unix epoch error = %v, want ErrTimestampOverflow", err)
+	}
+}
+
+// Returns an id at t from a new Generator, failing t on error.
+func mustGenerateAt(tb testing.TB, at time.Time) string {
+	tb.Helper()
+	id, err := NewGenerator().GenerateAt(at)
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	return id
+}
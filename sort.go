@@ -0,0 +1,63 @@
+package pushid
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Compare returns -1, 0 or +1 depending on whether a sorts before, equal to or after b. This is the
+// canonical ordering of push ids: a byte-wise comparison, which PUSH_CHARS is laid out to make
+// chronological. It is case-sensitive by design and can be passed to slices.SortFunc.
+func Compare(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+// Less reports whether a sorts before b in the order defined by Compare.
+func Less(a, b string) bool {
+	return Compare(a, b) < 0
+}
+
+// Equal reports whether a and b are the same push id. Like Compare it is byte-wise and case-sensitive.
+func Equal(a, b string) bool {
+	return a == b
+}
+
+// IDSlice attaches the methods of sort.Interface to []string, sorting in the order defined by Compare.
+type IDSlice []string
+
+func (s IDSlice) Len() int           { return len(s) }
+func (s IDSlice) Less(i, j int) bool { return Compare(s[i], s[j]) < 0 }
+func (s IDSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// Sort sorts ids, a []string or []PushID, in the order defined by Compare.
+func Sort[S ~[]E, E ~string](ids S) {
+	slices.SortFunc(ids, compare[E])
+}
+
+// IsSorted reports whether ids, a []string or []PushID, is sorted in the order defined by Compare.
+func IsSorted[S ~[]E, E ~string](ids S) bool {
+	return slices.IsSortedFunc(ids, compare[E])
+}
+
+// CheckMonotonic validates every element of ids, a []string or []PushID, and checks that each sorts strictly
+// after the one before it, in one pass. It returns the index of the first invalid or out-of-order element
+// with an error describing it, or -1 and nil. Unlike IsSorted it also rejects duplicates, which a merge of
+// id streams must never produce.
+func CheckMonotonic[S ~[]E, E ~string](ids S) (int, error) {
+	for i, id := range ids {
+		if err := Validate(string(id)); err != nil {
+			return i, err
+		}
+
+		if i > 0 && compare(ids[i-1], id) >= 0 {
+			return i, fmt.Errorf("Push id %q at index %d does not sort after %q", string(id), i, string(ids[i-1]))
+		}
+	}
+
+	return -1, nil
+}
+
+func compare[E ~string](a, b E) int {
+	return Compare(string(a), string(b))
+}
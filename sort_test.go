@@ -0,0 +1,117 @@
+package pushid
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"-JhLeOlGIEjaIOFHR0xd", "-JhLeOlGIEjaIOFHR0xe", -1},
+		{"-JhLeOlGIEjaIOFHR0xd", "-JhLeOlF-----------z", 1},
+		{"-JhLeOlGIEjaIOFHR0xd", "-JhLeOlGIEjaIOFHR0xd", 0},
+		{"-JhLeOlGIEjaIOFHR0xZ", "-JhLeOlGIEjaIOFHR0xa", -1},
+	} {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+
+		if got := Less(tt.a, tt.b); got != (tt.want < 0) {
+			t.Errorf("Less(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want < 0)
+		}
+
+		if got := Equal(tt.a, tt.b); got != (tt.want == 0) {
+			t.Errorf("Equal(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want == 0)
+		}
+	}
+}
+
+// Returns n ids from a generator seeded with seed, in generation order, and a shuffled copy of them.
+func shuffledIDs(t *testing.T, n int, seed int64) (ordered, shuffled []string) {
+	t.Helper()
+	g := NewGenerator(WithRand(rand.New(rand.NewSource(seed))))
+	ordered = make([]string, n)
+	for i := range ordered {
+		id, err := g.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ordered[i] = id
+	}
+
+	shuffled = slices.Clone(ordered)
+	rand.New(rand.NewSource(seed)).Shuffle(n, func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	return ordered, shuffled
+}
+
+func TestIDSlice(t *testing.T) {
+	ordered, shuffled := shuffledIDs(t, 1000, 1)
+
+	want := slices.Clone(shuffled)
+	sort.Strings(want)
+
+	sort.Sort(IDSlice(shuffled))
+	if !slices.Equal(shuffled, want) || !slices.Equal(shuffled, ordered) {
+		t.Error("sort.Sort(IDSlice) does not match sort.Strings and generation order")
+	}
+}
+
+func TestSort(t *testing.T) {
+	ordered, shuffled := shuffledIDs(t, 1000, 2)
+
+	ids := make([]PushID, len(shuffled))
+	for i, id := range shuffled {
+		ids[i] = PushID(id)
+	}
+
+	if IsSorted(ids) {
+		t.Fatal("IsSorted of shuffled ids reported true")
+	}
+
+	Sort(ids)
+	if !IsSorted(ids) {
+		t.Fatal("IsSorted after Sort reported false")
+	}
+
+	for i, id := range ids {
+		if string(id) != ordered[i] {
+			t.Fatalf("Sort put %q at index %d, generated %q there", id, i, ordered[i])
+		}
+	}
+
+	sort.Slice(shuffled, func(i, j int) bool { return Less(shuffled[i], shuffled[j]) })
+	if !slices.Equal(shuffled, ordered) {
+		t.Error("sort.Slice with Less does not restore generation order")
+	}
+}
+
+func TestCheckMonotonic(t *testing.T) {
+	ordered, _ := shuffledIDs(t, 100, 3)
+	if i, err := CheckMonotonic(ordered); i != -1 || err != nil {
+		t.Errorf("CheckMonotonic of generated ids = %d, %v, want -1, nil", i, err)
+	}
+
+	if i, err := CheckMonotonic([]PushID{}); i != -1 || err != nil {
+		t.Errorf("CheckMonotonic of no ids = %d, %v, want -1, nil", i, err)
+	}
+
+	for _, tt := range []struct {
+		name string
+		ids  []string
+		want int
+	}{
+		{"swapped", []string{ordered[0], ordered[2], ordered[1]}, 2},
+		{"duplicate", []string{ordered[0], ordered[1], ordered[1]}, 2},
+		{"invalid", []string{ordered[0], "bad", ordered[1]}, 1},
+	} {
+		if i, err := CheckMonotonic(tt.ids); i != tt.want || err == nil {
+			t.Errorf("CheckMonotonic of %s ids = %d, %v, want %d and an error", tt.name, i, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package pushid
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Stream returns a channel of push ids kept topped up by a goroutine that generates ahead into a buffer of
+// the given size. IDs arrive in the order they were generated, so they are strictly increasing. The channel
+// is closed once ctx is done or generation fails, and the goroutine exits with it.
+func (g *Generator) Stream(ctx context.Context, buffer int) <-chan string {
+	ch := make(chan string, buffer)
+
+	go func() {
+		defer close(ch)
+
+		for ctx.Err() == nil {
+			id, err := g.Generate()
+			if err != nil {
+				return
+			}
+
+			select {
+			case ch <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// Reader returns an endless io.Reader of push ids, each followed by sep, generated lazily as they are
+// read. Each Read fills p with as many ids as fit and keeps the rest of a partially read id for the next
+// call, so io.Copy(os.Stdout, g.Reader('\n')) prints one id per line.
+func (g *Generator) Reader(sep byte) io.Reader {
+	return &idReader{g: g, sep: sep}
+}
+
+type idReader struct {
+	g   *Generator
+	sep byte
+
+	// Holds the current id and separator; unread is the part of it not yet returned.
+	buf    [32]byte
+	unread []byte
+}
+
+func (r *idReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(r.unread) == 0 {
+			b, err := r.g.Append(r.buf[:0])
+			if err != nil {
+				return n, err
+			}
+
+			r.unread = append(b, r.sep)
+		}
+
+		c := copy(p[n:], r.unread)
+		r.unread = r.unread[c:]
+		n += c
+	}
+
+	return n, nil
+}
+
+// WriteN generates n push ids and writes each to w followed by sep, returning the number of bytes written.
+// The ids are built in a single reused buffer. The first write error stops generation and is returned.
+func (g *Generator) WriteN(w io.Writer, n int, sep []byte) (int, error) {
+	if n < 0 {
+		return 0, fmt.Errorf("Invalid count %d, should not be negative", n)
+	}
+
+	buf := make([]byte, 0, idLen+len(sep))
+	written := 0
+	for i := 0; i < n; i++ {
+		b, err := g.Append(buf[:0])
+		if err != nil {
+			return written, err
+		}
+
+		c, err := w.Write(append(b, sep...))
+		written += c
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
@@ -0,0 +1,118 @@
+package pushid
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := NewGenerator().Stream(ctx, 16)
+
+	ids := make([]string, 1000)
+	for i := range ids {
+		ids[i] = <-ch
+	}
+
+	cancel()
+	for range ch {
+	}
+
+	if i, err := CheckMonotonic(ids); err != nil {
+		t.Errorf("streamed ids are not strictly increasing at index %d: %v", i, err)
+	}
+
+	// The goroutine exits after closing the channel, so give it a moment.
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("%d goroutines running after the stream closed, %d before it started", n, before)
+	}
+}
+
+func TestStreamGenerationError(t *testing.T) {
+	ch := NewGenerator(WithEntropy(errReader{})).Stream(context.Background(), 0)
+	if id, ok := <-ch; ok {
+		t.Errorf("stream with failing entropy yielded %q, want a closed channel", id)
+	}
+}
+
+func TestReader(t *testing.T) {
+	// 10 ids and their separators, then half of another, read in odd-sized chunks.
+	const n = 10*(idLen+1) + idLen/2
+	buf := make([]byte, n)
+	r := NewGenerator().Reader('\n')
+	for off := 0; off < n; {
+		end := min(off+7, n)
+		c, err := r.Read(buf[off:end])
+		if err != nil || c != end-off {
+			t.Fatalf("Read of %d bytes = %d, %v", end-off, c, err)
+		}
+
+		off = end
+	}
+
+	lines := strings.Split(string(buf), "\n")
+	if len(lines) != 11 || len(lines[10]) != idLen/2 {
+		t.Fatalf("read %q, want 10 ids and half of another", buf)
+	}
+
+	if i, err := CheckMonotonic(lines[:10]); err != nil {
+		t.Errorf("read ids are not strictly increasing at index %d: %v", i, err)
+	}
+
+	if _, err := NewGenerator(WithEntropy(errReader{})).Reader(' ').Read(buf); err == nil {
+		t.Error("Read with failing entropy returned no error")
+	}
+}
+
+func TestWriteN(t *testing.T) {
+	var buf bytes.Buffer
+	n, err := NewGenerator().WriteN(&buf, 100, []byte("\r\n"))
+	if err != nil || n != 100*(idLen+2) || buf.Len() != n {
+		t.Fatalf("WriteN = %d, %v, wrote %d bytes, want %d", n, err, buf.Len(), 100*(idLen+2))
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if i, err := CheckMonotonic(lines); len(lines) != 100 || err != nil {
+		t.Errorf("wrote %d ids, out of order at index %d: %v", len(lines), i, err)
+	}
+
+	if _, err := NewGenerator().WriteN(io.Discard, -1, nil); err == nil {
+		t.Error("WriteN of -1 ids returned no error")
+	}
+
+	w := &limitedWriter{limit: 3 * idLen}
+	if n, err := NewGenerator().WriteN(w, 10, nil); !errors.Is(err, errWriteLimit) || n != 3*idLen {
+		t.Errorf("WriteN to a failing writer = %d, %v, want %d and its error", n, err, 3*idLen)
+	}
+}
+
+var errWriteLimit = errors.New("write limit reached")
+
+// An io.Writer that accepts limit bytes and then fails.
+type limitedWriter struct {
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		n := w.limit
+		w.limit = 0
+		return n, errWriteLimit
+	}
+
+	w.limit -= len(p)
+	return len(p), nil
+}
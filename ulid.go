@@ -0,0 +1,31 @@
+package pushid
+
+import (
+	"fmt"
+)
+
+// ToULID converts id to a ULID, 26 characters of Crockford base32. The 48-bit timestamp carries over
+// exactly; the 72 random bits become the top of the ULID's 80 and the remaining 8 are zero. ULIDs made this
+// way sort in the same order as the ids they came from, and FromULID recovers the id exactly.
+func ToULID(id PushID) (string, error) {
+	if err := Validate(string(id)); err != nil {
+		return "", err
+	}
+
+	var u [16]byte
+	b := id.Bytes()
+	copy(u[:], b[:])
+	return encodeCrockford(u[:]), nil
+}
+
+// FromULID converts a ULID to a push id with the same timestamp. The last 8 of the ULID's 80 random bits
+// do not fit and are dropped, so only ULIDs produced by ToULID round-trip exactly. Decoding is
+// case-insensitive.
+func FromULID(s string) (PushID, error) {
+	var u [16]byte
+	if err := decodeCrockford(u[:], s); err != nil {
+		return "", fmt.Errorf("Invalid ULID: %w", err)
+	}
+
+	return FromBytes([15]byte(u[:15])), nil
+}
@@ -0,0 +1,43 @@
+package pushid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestULIDRoundTrip(t *testing.T) {
+	ordered, _ := shuffledIDs(t, 500, 5)
+	ordered = append([]string{string(Nil)}, append(ordered, string(Max))...)
+
+	ulids := make([]string, len(ordered))
+	for i, id := range ordered {
+		u, err := ToULID(PushID(id))
+		if err != nil || len(u) != 26 {
+			t.Fatalf("ToULID(%q) = %q, %v", id, u, err)
+		}
+
+		if got, err := FromULID(u); err != nil || string(got) != id {
+			t.Errorf("FromULID(ToULID(%q)) = %q, %v", id, got, err)
+		}
+
+		if got, err := FromULID(strings.ToLower(u)); err != nil || string(got) != id {
+			t.Errorf("FromULID of lowercase %q = %q, %v", u, got, err)
+		}
+
+		ulids[i] = u
+	}
+
+	if i, err := CheckMonotonic(ordered); err != nil || !IsSorted(ulids) {
+		t.Errorf("ULIDs do not sort like the ids (index %d: %v)", i, err)
+	}
+
+	if _, err := ToULID("bad"); err == nil {
+		t.Error("ToULID of an invalid id returned no error")
+	}
+
+	for _, s := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA", "01ARZ3NDEKTSV4RRFFQ69G5FAU", "81ARZ3NDEKTSV4RRFFQ69G5FAV"} {
+		if _, err := FromULID(s); err == nil {
+			t.Errorf("FromULID(%q) returned no error", s)
+		}
+	}
+}
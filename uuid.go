@@ -0,0 +1,44 @@
+package pushid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// ToUUID converts id to a version 7 UUID (RFC 9562). The 48-bit timestamp fills unix_ts_ms, the first 12
+// random bits fill rand_a and the other 60 the top of rand_b, whose lowest 2 bits are zero. The version
+// and variant bits are constant, so UUIDs compare byte-wise in the same order as the ids they came from.
+// An invalid id converts like Nil.
+func ToUUID(id PushID) [16]byte {
+	b := id.Bytes()
+	ts := uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 | uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5])
+	randHi, randLo := binary.BigEndian.Uint64(b[6:14]), uint64(b[14])
+
+	var u [16]byte
+	binary.BigEndian.PutUint64(u[:8], ts<<16|0x7<<12|randHi>>52)
+	binary.BigEndian.PutUint64(u[8:], 0x2<<62|((randHi&(1<<52-1))<<8|randLo)<<2)
+	return u
+}
+
+// FromUUID converts a UUID produced by ToUUID back to the id it came from. Any other UUID, one that is not
+// version 7 with the RFC 9562 variant and the lowest 2 bits clear, is rejected rather than converted
+// lossily.
+func FromUUID(u [16]byte) (PushID, error) {
+	hi, lo := binary.BigEndian.Uint64(u[:8]), binary.BigEndian.Uint64(u[8:])
+	if hi>>12&0xf != 7 || lo>>62 != 0x2 || lo&0x3 != 0 {
+		return "", fmt.Errorf("UUID %x was not produced by ToUUID", u)
+	}
+
+	rb := lo >> 2 & (1<<60 - 1)
+	randHi, randLo := (hi&0xfff)<<52|rb>>8, byte(rb)
+
+	var b [15]byte
+	ts := hi >> 16
+	for i := 0; i < 6; i++ {
+		b[i] = byte(ts >> (40 - 8*i))
+	}
+
+	binary.BigEndian.PutUint64(b[6:14], randHi)
+	b[14] = randLo
+	return FromBytes(b), nil
+}
@@ -0,0 +1,43 @@
+package pushid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUUIDRoundTrip(t *testing.T) {
+	_, ids := shuffledIDs(t, 500, 4)
+	ids = append(ids, string(Nil), string(Max), string(knownID))
+	for _, id := range ids {
+		u := ToUUID(PushID(id))
+		if u[6]>>4 != 7 || u[8]>>6 != 0x2 {
+			t.Fatalf("ToUUID(%q) = %x, want version 7 and the RFC 9562 variant", id, u)
+		}
+
+		if got, err := FromUUID(u); err != nil || string(got) != id {
+			t.Errorf("FromUUID(ToUUID(%q)) = %q, %v", id, got, err)
+		}
+	}
+
+	for i := range ids {
+		for j := range ids {
+			a, b := ToUUID(PushID(ids[i])), ToUUID(PushID(ids[j]))
+			if bytes.Compare(a[:], b[:]) != Compare(ids[i], ids[j]) {
+				t.Fatalf("UUIDs of %q and %q compare unlike the ids", ids[i], ids[j])
+			}
+		}
+	}
+
+	u := ToUUID(knownID)
+	for _, bad := range []func(u *[16]byte){
+		func(u *[16]byte) { u[6] = 0x40 | u[6]&0x0f },
+		func(u *[16]byte) { u[8] &^= 0x80 },
+		func(u *[16]byte) { u[15] |= 1 },
+	} {
+		v := u
+		bad(&v)
+		if _, err := FromUUID(v); err == nil {
+			t.Errorf("FromUUID(%x) returned no error", v)
+		}
+	}
+}
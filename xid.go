@@ -0,0 +1,41 @@
+package pushid
+
+import (
+	"encoding/base32"
+	"fmt"
+)
+
+// The xid alphabet is lowercase base32hex, which is in ascending byte order.
+var xidEncoding = base32.NewEncoding("0123456789abcdefghijklmnopqrstuv").WithPadding(base32.NoPadding)
+
+// ToXID converts id to an xid, 20 characters of lowercase base32hex. xids hold the same 12 bytes as
+// ObjectIDs: whole seconds, then 8 bytes that xid splits into machine, pid and counter and that are taken
+// from the first 64 random bits. The conversion is lossy in the same way as ToObjectID, and xids of ids
+// in different seconds sort like the ids.
+func ToXID(id PushID) (string, error) {
+	b, err := toSecondsLayout(id, "xid")
+	if err != nil {
+		return "", err
+	}
+
+	return xidEncoding.EncodeToString(b[:]), nil
+}
+
+// FromXID converts an xid to a push id, as FromObjectID does for the same 12 bytes.
+func FromXID(s string) (PushID, error) {
+	if len(s) != 20 {
+		return "", fmt.Errorf("%w: xid %q is %d characters, should be 20", ErrInvalidLength, s, len(s))
+	}
+
+	var b [12]byte
+	if _, err := xidEncoding.Decode(b[:], []byte(s)); err != nil {
+		return "", fmt.Errorf("%w: xid %q: %v", ErrInvalidCharacter, s, err)
+	}
+
+	// The last character carries 4 unused bits, which the decoder ignores; only accept them as zero.
+	if xidEncoding.EncodeToString(b[:]) != s {
+		return "", fmt.Errorf("%w: xid %q has non-zero trailing bits", ErrInvalidCharacter, s)
+	}
+
+	return fromSecondsLayout(b), nil
+}
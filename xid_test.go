@@ -0,0 +1,51 @@
+package pushid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestXIDRoundTrip(t *testing.T) {
+	g := NewGenerator()
+	var prev string
+	for s := 0; s < 100; s++ {
+		at := testTime.Add(time.Duration(s)*time.Second + 250*time.Millisecond)
+		id, _ := g.GenerateAt(at)
+
+		x, err := ToXID(PushID(id))
+		if err != nil || len(x) != 20 {
+			t.Fatalf("ToXID(%q) = %q, %v", id, x, err)
+		}
+
+		if x <= prev {
+			t.Fatalf("xid %q of %q does not sort after %q", x, id, prev)
+		}
+
+		back, err := FromXID(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		oid, _ := ToObjectID(PushID(id))
+		if back != FromObjectID(oid) {
+			t.Errorf("FromXID(ToXID(%q)) = %q, want %q as for ObjectIDs", id, back, FromObjectID(oid))
+		}
+
+		prev = x
+	}
+
+	for _, tt := range []struct {
+		s    string
+		want error
+	}{
+		{"9m4e2mr0ui3e8a215n4", ErrInvalidLength},
+		{"9m4e2mr0ui3e8a215n4gg", ErrInvalidLength},
+		{"9m4e2mr0ui3e8a215n4w", ErrInvalidCharacter},
+		{"9m4e2mr0ui3e8a215n4h", ErrInvalidCharacter},
+	} {
+		if _, err := FromXID(tt.s); !errors.Is(err, tt.want) {
+			t.Errorf("FromXID(%q) error = %v, want %v", tt.s, err, tt.want)
+		}
+	}
+}